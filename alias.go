@@ -0,0 +1,75 @@
+package yulid
+
+import "sync"
+
+// maxAliasDepth bounds alias resolution so a misconfigured cycle cannot loop forever
+const maxAliasDepth = 16
+
+var (
+	aliasMu sync.RWMutex
+	aliases = map[string]string{}
+)
+
+// AddAlias registers oldPrefix as an alias of canonicalPrefix, so IDs issued under
+// oldPrefix route to the same tenant as canonicalPrefix. Aliases may chain.
+//
+// AddAlias is intended to be called during setup; CanonicalPrefix is safe for
+// concurrent use once registration is complete.
+func AddAlias(oldPrefix, canonicalPrefix string) {
+	aliasMu.Lock()
+	defer aliasMu.Unlock()
+	aliases[oldPrefix] = canonicalPrefix
+}
+
+// CanonicalPrefix resolves prefix through the alias registry and returns the
+// prefix it currently routes to. Unregistered prefixes are returned unchanged.
+func CanonicalPrefix(prefix string) string {
+	aliasMu.RLock()
+	defer aliasMu.RUnlock()
+
+	for i := 0; i < maxAliasDepth; i++ {
+		next, ok := aliases[prefix]
+		if !ok || next == prefix {
+			break
+		}
+		prefix = next
+	}
+
+	return prefix
+}
+
+// SameTenant reports whether a and b route to the same tenant, comparing their
+// prefixes after alias resolution.
+func SameTenant(a, b YULID) bool {
+	return CanonicalPrefix(a.Prefix()) == CanonicalPrefix(b.Prefix())
+}
+
+// HasMeaningfulPrefix reports whether yd's prefix is a known tenant prefix,
+// meaning it is in the configured allowlist or appears in the alias registry
+// as either an alias or a canonical prefix.
+//
+// It is a heuristic for telling customer IDs from system IDs such as those
+// minted by NewRandomPrefix, and is only as accurate as the registries: a
+// random prefix that happens to match a registered one reports true.
+func (yd YULID) HasMeaningfulPrefix() bool {
+	prefix := yd.Prefix()
+
+	allowedMu.RLock()
+	_, ok := allowed[prefix]
+	allowedMu.RUnlock()
+	if ok {
+		return true
+	}
+
+	aliasMu.RLock()
+	defer aliasMu.RUnlock()
+	if _, ok := aliases[prefix]; ok {
+		return true
+	}
+	for _, canonical := range aliases {
+		if canonical == prefix {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package yulid
+
+import "testing"
+
+// withAliases registers pairs of old and canonical prefixes for the duration of t
+func withAliases(t *testing.T, pairs ...string) {
+	t.Helper()
+	for i := 0; i < len(pairs); i += 2 {
+		AddAlias(pairs[i], pairs[i+1])
+	}
+	t.Cleanup(func() {
+		aliasMu.Lock()
+		defer aliasMu.Unlock()
+		for i := 0; i < len(pairs); i += 2 {
+			delete(aliases, pairs[i])
+		}
+	})
+}
+
+func TestCanonicalPrefix(t *testing.T) {
+	withAliases(t, "OLDA", "MIDA", "MIDA", "NEWA", "LOOP", "POOL", "POOL", "LOOP")
+
+	tests := []struct {
+		prefix, want string
+	}{
+		{"NEWA", "NEWA"}, // canonical
+		{"MIDA", "NEWA"}, // direct alias
+		{"OLDA", "NEWA"}, // chained alias
+		{"JNDE", "JNDE"}, // unregistered
+	}
+	for _, tt := range tests {
+		if got := CanonicalPrefix(tt.prefix); got != tt.want {
+			t.Errorf("CanonicalPrefix(%q) = %q, want %q", tt.prefix, got, tt.want)
+		}
+	}
+
+	// a cycle terminates on one of its members
+	if got := CanonicalPrefix("LOOP"); got != "LOOP" && got != "POOL" {
+		t.Errorf("CanonicalPrefix on a cycle = %q", got)
+	}
+}
+
+func TestSameTenant(t *testing.T) {
+	withAliases(t, "OLDA", "NEWA")
+
+	old, cur := MustParse("OLDA-ED24HS"), MustParse("NEWA-ED24HS")
+	if !SameTenant(cur, MustParse("NEWA-AB12")) {
+		t.Error("SameTenant = false for identical prefixes")
+	}
+	if !SameTenant(old, cur) {
+		t.Errorf("SameTenant(%q, %q) = false across an alias", old, cur)
+	}
+	if SameTenant(old, MustParse("JNDE-ED24HS")) {
+		t.Error("SameTenant matched unrelated prefixes")
+	}
+}
+
+func TestHasMeaningfulPrefix(t *testing.T) {
+	withAliases(t, "OLDA", "NEWA")
+	SetAllowedPrefixes([]string{"JNDE"})
+	defer SetAllowedPrefixes(nil)
+
+	for _, s := range []string{"OLDA-ED24HS", "NEWA-ED24HS", "JNDE-ED24HS"} {
+		if !MustParse(s).HasMeaningfulPrefix() {
+			t.Errorf("HasMeaningfulPrefix(%q) = false for a registered prefix", s)
+		}
+	}
+	if id := MustParse("QZXW-ED24HS"); id.HasMeaningfulPrefix() {
+		t.Errorf("HasMeaningfulPrefix(%q) = true for an unregistered prefix", id)
+	}
+}
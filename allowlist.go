@@ -0,0 +1,55 @@
+package yulid
+
+import (
+	"errors"
+	"sync"
+)
+
+var (
+	ErrPrefixNotAllowed = errors.New("prefix is not in the allowed prefix list")
+)
+
+var (
+	allowedMu sync.RWMutex
+	allowed   map[string]struct{}
+)
+
+// SetAllowedPrefixes restricts New to the given prefixes. An empty list removes
+// the restriction, which is the default.
+func SetAllowedPrefixes(prefixes []string) {
+	allowedMu.Lock()
+	defer allowedMu.Unlock()
+
+	if len(prefixes) == 0 {
+		allowed = nil
+		return
+	}
+	allowed = make(map[string]struct{}, len(prefixes))
+	for _, p := range prefixes {
+		allowed[p] = struct{}{}
+	}
+}
+
+// prefixAllowed reports whether prefix passes the configured allowlist
+func prefixAllowed(prefix string) bool {
+	allowedMu.RLock()
+	defer allowedMu.RUnlock()
+
+	if allowed == nil {
+		return true
+	}
+	_, ok := allowed[prefix]
+	return ok
+}
+
+// ValidateAllowed checks that id is correctly formatted and that its prefix is
+// permitted by the allowlist.
+func ValidateAllowed(id YULID) error {
+	if err := Validate(id); err != nil {
+		return err
+	}
+	if !prefixAllowed(id.Prefix()) {
+		return ErrPrefixNotAllowed
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package yulid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAllowedPrefixes(t *testing.T) {
+	defer SetAllowedPrefixes(nil)
+
+	// unconfigured: every prefix is allowed
+	if _, err := New("ZQXW"); err != nil {
+		t.Fatalf("New without an allowlist: %v", err)
+	}
+	if err := ValidateAllowed(MustParse("ZQXW-AB12")); err != nil {
+		t.Fatalf("ValidateAllowed without an allowlist: %v", err)
+	}
+
+	SetAllowedPrefixes([]string{"JNDE", "ACME"})
+	id, err := New("ACME")
+	if err != nil {
+		t.Fatalf("New with an allowed prefix: %v", err)
+	}
+	if err := ValidateAllowed(id); err != nil {
+		t.Fatalf("ValidateAllowed(%q): %v", id, err)
+	}
+	if _, err := New("ZQXW"); !errors.Is(err, ErrPrefixNotAllowed) {
+		t.Fatalf("New with a disallowed prefix: err = %v, want ErrPrefixNotAllowed", err)
+	}
+	disallowed := MustParse("ZQXW-AB12")
+	if err := ValidateAllowed(disallowed); !errors.Is(err, ErrPrefixNotAllowed) {
+		t.Fatalf("ValidateAllowed(%q): err = %v, want ErrPrefixNotAllowed", disallowed, err)
+	}
+	if err := Validate(disallowed); err != nil {
+		t.Fatalf("Validate enforced the allowlist: %v", err)
+	}
+	if err := ValidateAllowed(YULID{}); errors.Is(err, ErrPrefixNotAllowed) || err == nil {
+		t.Fatalf("ValidateAllowed of the zero YULID: err = %v, want a format error", err)
+	}
+
+	SetAllowedPrefixes([]string{})
+	if _, err := New("ZQXW"); err != nil {
+		t.Fatalf("New after clearing the allowlist: %v", err)
+	}
+}
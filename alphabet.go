@@ -0,0 +1,70 @@
+package yulid
+
+import "strings"
+
+const (
+	// AlphabetAlphanumeric is the default suffix alphabet, A-Z and 0-9
+	AlphabetAlphanumeric = alphanumeric
+
+	// AlphabetHumanSafe is Crockford's base32 alphabet: 0-9 and A-Z without I,
+	// L, O and U. The digits 0 and 1 are kept as the canonical readings of the
+	// look-alikes O, I and L, which never appear in generated suffixes, so
+	// WithNormalizeAmbiguous can map a misread character back unambiguously.
+	AlphabetHumanSafe = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+	// AlphabetLetters is A-Z, for Format segments that hold only letters
+	AlphabetLetters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+	// AlphabetDigits is 0-9, for Format segments that hold only digits
+	AlphabetDigits = "0123456789"
+)
+
+// WithFirstCharFromSet draws the first suffix character uniformly from set
+// instead of the whole alphabet, for stores that shard by that character: a
+// set of "ABCD" confines a prefix's IDs to four shards. Every character of set
+// must be in the alphabet, and Validate requires the first character to be in
+// set. The keyspace shrinks by a factor of len(set)/len(alphabet). It cannot
+// be combined with WithSuffixStrategy.
+//
+// Without this option the first character is already uniform over the
+// alphabet, as is every other.
+func WithFirstCharFromSet(set string) Option {
+	return func(o *options) {
+		o.firstSet = set
+	}
+}
+
+// WithNormalizeAmbiguous makes Parse rewrite suffix characters outside the
+// alphabet to their canonical form before validating, and makes Validate
+// accept them: lowercase letters become uppercase, then O becomes 0 and I and L
+// become 1. A character is only rewritten when it is not in the alphabet and
+// its canonical form is, so it is mostly useful with AlphabetHumanSafe.
+// Prefixes are never rewritten.
+func WithNormalizeAmbiguous() Option {
+	return func(o *options) {
+		o.normalize = true
+	}
+}
+
+// normalizeSuffix rewrites the suffix characters of id that are outside the
+// alphabet but whose canonical reading is in it
+func (o options) normalizeSuffix(id *YULID) {
+	for i := prefixLen + separatorLen; i < len(id) && id[i] != 0; i++ {
+		c := id[i]
+		if strings.IndexByte(o.alphabet, c) >= 0 {
+			continue
+		}
+		if c >= 'a' && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		if strings.IndexByte(o.alphabet, c) < 0 {
+			c = ambiguous[c]
+		}
+		if c != 0 && strings.IndexByte(o.alphabet, c) >= 0 {
+			id[i] = c
+		}
+	}
+}
+
+// ambiguous maps look-alike letters to the digits they are mistaken for
+var ambiguous = [256]byte{'O': '0', 'I': '1', 'L': '1'}
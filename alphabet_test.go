@@ -0,0 +1,123 @@
+package yulid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// chiSquare returns the chi-square statistic of the first suffix characters
+// of ids against a uniform distribution over set
+func chiSquare(ids []YULID, set string) float64 {
+	counts := make(map[byte]int, len(set))
+	for _, id := range ids {
+		counts[id.SuffixBytes()[0]]++
+	}
+	expected := float64(len(ids)) / float64(len(set))
+	var x2 float64
+	for i := 0; i < len(set); i++ {
+		d := float64(counts[set[i]]) - expected
+		x2 += d * d / expected
+	}
+	return x2
+}
+
+func TestFirstCharUniform(t *testing.T) {
+	batch, err := defaultGenerator().NewBatch("JNDE", 36000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 35 degrees of freedom; 66.6 is the 0.1% critical value
+	if x2 := chiSquare(batch, alphanumeric); x2 > 66.6 {
+		t.Fatalf("first characters are not uniform: chi-square %.1f", x2)
+	}
+}
+
+func TestWithFirstCharFromSet(t *testing.T) {
+	const set = "ABCD"
+	g, err := NewGenerator(WithFirstCharFromSet(set))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := make([]YULID, 0, 4000)
+	for i := 0; i < 2000; i++ {
+		ids = append(ids, mustNew(t, g, "JNDE"))
+	}
+	batch, err := g.NewBatch("JNDE", 2000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids = append(ids, batch...)
+	derived, err := g.FromUint64("JNDE", 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids = append(ids, derived)
+
+	for _, id := range ids {
+		if !strings.ContainsRune(set, rune(id.Suffix()[0])) {
+			t.Fatalf("%q starts its suffix outside %q", id, set)
+		}
+		if err := g.Validate(id); err != nil {
+			t.Fatalf("Validate(%q): %v", id, err)
+		}
+	}
+	// 3 degrees of freedom; 16.3 is the 0.1% critical value
+	if x2 := chiSquare(ids, set); x2 > 16.3 {
+		t.Fatalf("first characters are not uniform over %q: chi-square %.1f", set, x2)
+	}
+
+	if err := g.Validate(MustParse("JNDE-ZBCDEF")); !errors.Is(err, ErrInvalidSuffix) {
+		t.Fatalf("Validate accepted a first character outside the set: %v", err)
+	}
+}
+
+func TestWithFirstCharFromSetRejectsBadSets(t *testing.T) {
+	for _, opts := range [][]Option{
+		{WithFirstCharFromSet("AA")},
+		{WithFirstCharFromSet("a")},
+		{WithFirstCharFromSet("A"), WithSuffixStrategy(CryptoRandom{})},
+	} {
+		if _, err := NewGenerator(opts...); !errors.Is(err, ErrInvalidOption) {
+			t.Errorf("NewGenerator: err = %v, want ErrInvalidOption", err)
+		}
+	}
+}
+
+func TestNormalizeAmbiguous(t *testing.T) {
+	g, err := NewGenerator(WithAlphabet(AlphabetHumanSafe), WithNormalizeAmbiguous())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"JNDE-0112AB", "JNDE-OIL2AB", "JNDE-oil2ab", "JNDE-0iL2aB"} {
+		got, err := g.Parse(s)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", s, err)
+			continue
+		}
+		if got.String() != "JNDE-0112AB" {
+			t.Errorf("Parse(%q) = %q, want JNDE-0112AB", s, got)
+		}
+
+		var raw YULID
+		copy(raw[:], s)
+		if err := g.Validate(raw); err != nil {
+			t.Errorf("Validate(%q): %v", s, err)
+		}
+	}
+
+	// without the option the look-alikes are outside the alphabet
+	if _, err := Parse("JNDE-OIL2AB", WithAlphabet(AlphabetHumanSafe)); !errors.Is(err, ErrInvalidSuffix) {
+		t.Errorf("strict Parse of look-alikes: err = %v, want ErrInvalidSuffix", err)
+	}
+	// prefixes are never rewritten, and U has no canonical reading
+	for _, s := range []string{"jnde-AB12CD", "JNDE-AB12CU"} {
+		if got, err := g.Parse(s); err == nil {
+			t.Errorf("Parse(%q) = %q, want an error", s, got)
+		}
+	}
+	// characters already in the alphabet are left alone
+	if got, err := Parse("JNDE-OIL2ab", WithNormalizeAmbiguous()); err != nil || got.String() != "JNDE-OIL2AB" {
+		t.Errorf("Parse with the default alphabet = %q, %v; want JNDE-OIL2AB", got, err)
+	}
+}
@@ -0,0 +1,117 @@
+package yulid
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var (
+	ErrKeyspaceExhausted = errors.New("could not generate a unique YULID within the retry budget")
+
+	// ErrExhausted is the same error as ErrKeyspaceExhausted, returned by
+	// Generator.New when every candidate was rejected
+	ErrExhausted = ErrKeyspaceExhausted
+
+	ErrNegativeBatchSize = errors.New("batch size must not be negative")
+)
+
+// defaultMaxRetries bounds how many candidates uniqueness-constrained generation draws
+const defaultMaxRetries = 100
+
+// WriteBatch generates n unique YULIDs for prefix and writes each to w followed
+// by delim, returning how many were written. The IDs come from NewBatch on the
+// default Generator, so duplicates are regenerated within its retry limit and
+// an n beyond its suffix keyspace fails with ErrExhausted before anything is
+// written.
+func WriteBatch(w io.Writer, prefix string, n int, delim byte) (int, error) {
+	batch, err := defaultGenerator().NewBatch(prefix, n)
+	if err != nil {
+		return 0, err
+	}
+
+	bw := bufio.NewWriter(w)
+	for i, id := range batch {
+		if _, err := bw.WriteString(id.String()); err != nil {
+			return i, err
+		}
+		if err := bw.WriteByte(delim); err != nil {
+			return i, err
+		}
+	}
+
+	return len(batch), bw.Flush()
+}
+
+// NewExcluding generates a YULID for prefix that is not already in existing.
+// It gives up with ErrKeyspaceExhausted once the retry limit of the default
+// Generator is spent on colliding candidates.
+func NewExcluding(prefix string, existing Set) (YULID, error) {
+	g := defaultGenerator()
+	for i := 0; i <= g.opts.maxRetries(); i++ {
+		id, err := g.New(prefix)
+		if err != nil {
+			return YULID{}, err
+		}
+		if !existing.Contains(id) {
+			return id, nil
+		}
+	}
+	return YULID{}, ErrKeyspaceExhausted
+}
+
+// NewBatchMinDistance generates n YULIDs for prefix whose suffixes pairwise
+// differ in at least k positions, so a single mistyped character cannot land on
+// another ID in the batch when k >= 2.
+//
+// Each candidate is checked against every accepted ID, and the chance of a
+// candidate being rejected rises steeply with k, so cost grows quickly with both
+// n and k. It returns ErrKeyspaceExhausted when the retry limit of the default
+// Generator is spent on consecutive candidates that fail the constraint, and an
+// error matching ErrInvalidOption when k is negative or longer than a suffix.
+func NewBatchMinDistance(prefix string, n, k int) ([]YULID, error) {
+	if k < 0 || k > maxSuffixLen {
+		return nil, fmt.Errorf("%w: minimum distance %d is out of range", ErrInvalidOption, k)
+	}
+	if n < 0 {
+		return nil, ErrNegativeBatchSize
+	}
+
+	g := defaultGenerator()
+	batch := make([]YULID, 0, n)
+	for len(batch) < n {
+		accepted := false
+		for i := 0; i <= g.opts.maxRetries() && !accepted; i++ {
+			id, err := g.New(prefix)
+			if err != nil {
+				return nil, err
+			}
+			if minHamming(id, batch) >= k {
+				batch = append(batch, id)
+				accepted = true
+			}
+		}
+		if !accepted {
+			return nil, ErrKeyspaceExhausted
+		}
+	}
+
+	return batch, nil
+}
+
+// minHamming returns the smallest suffix Hamming distance between id and the
+// members of batch, or maxSuffixLen if batch is empty.
+func minHamming(id YULID, batch []YULID) int {
+	best := maxSuffixLen
+	for _, other := range batch {
+		d := 0
+		for i := prefixLen + separatorLen; i < maxLen; i++ {
+			if id[i] != other[i] {
+				d++
+			}
+		}
+		best = min(best, d)
+	}
+	return best
+}
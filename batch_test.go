@@ -0,0 +1,175 @@
+package yulid
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestBatchNegativeSize(t *testing.T) {
+	if _, err := NewBatchMinDistance("JNDE", -1, 2); !errors.Is(err, ErrNegativeBatchSize) {
+		t.Errorf("NewBatchMinDistance(n = -1): err = %v, want ErrNegativeBatchSize", err)
+	}
+	if _, err := WriteBatch(&bytes.Buffer{}, "JNDE", -1, '\n'); !errors.Is(err, ErrNegativeBatchSize) {
+		t.Errorf("WriteBatch(n = -1): err = %v, want ErrNegativeBatchSize", err)
+	}
+	if _, err := defaultGenerator().NewBatch("JNDE", -1); !errors.Is(err, ErrNegativeBatchSize) {
+		t.Errorf("NewBatch(n = -1): err = %v, want ErrNegativeBatchSize", err)
+	}
+	if _, err := NewTimedBatch("JNDE", sortableEpoch, -1); !errors.Is(err, ErrNegativeBatchSize) {
+		t.Errorf("NewTimedBatch(n = -1): err = %v, want ErrNegativeBatchSize", err)
+	}
+}
+
+func TestNewBatchMinDistance(t *testing.T) {
+	batch, err := NewBatchMinDistance("JNDE", 50, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(batch) != 50 {
+		t.Fatalf("got %d IDs, want 50", len(batch))
+	}
+	for i, id := range batch {
+		if d := minHamming(id, batch[:i]); d < 3 {
+			t.Fatalf("%q is %d characters from an earlier ID", id, d)
+		}
+	}
+	for _, k := range []int{-1, maxSuffixLen + 1} {
+		if _, err := NewBatchMinDistance("JNDE", 1, k); !errors.Is(err, ErrInvalidOption) {
+			t.Errorf("NewBatchMinDistance(k = %d): err = %v, want ErrInvalidOption", k, err)
+		}
+	}
+}
+
+func TestNewBatchMinDistanceExhausted(t *testing.T) {
+	defer Configure()
+	const retries = 10
+	var drawn int
+	count := WithValidator(func(YULID) error { drawn++; return nil })
+	if err := Configure(WithAlphabet("AB"), WithSuffixLength(4), WithMaxRetries(retries), count); err != nil {
+		t.Fatal(err)
+	}
+
+	batch, err := NewBatchMinDistance("JNDE", 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := minHamming(batch[1], batch[:1]); d < 3 {
+		t.Fatalf("%q and %q are %d apart, want at least 3", batch[0], batch[1], d)
+	}
+	// over AB^4 only complementary pairs such as ABBA and BAAB are 4 apart,
+	// so no third ID can be, and each ID gets at most retries+1 candidates
+	drawn = 0
+	if _, err := NewBatchMinDistance("JNDE", 3, 4); !errors.Is(err, ErrKeyspaceExhausted) {
+		t.Fatalf("NewBatchMinDistance beyond the possible: err = %v, want ErrKeyspaceExhausted", err)
+	}
+	if limit := 3 * (retries + 1); drawn > limit {
+		t.Fatalf("NewBatchMinDistance drew %d candidates, want at most %d", drawn, limit)
+	}
+}
+
+func TestWriteBatch(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := WriteBatch(&buf, "JNDE", 100, '\n')
+	if err != nil || n != 100 {
+		t.Fatalf("WriteBatch = %d, %v", n, err)
+	}
+	seen := make(Set)
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		id, err := Parse(line)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seen.Contains(id) {
+			t.Fatalf("%q written twice", id)
+		}
+		seen.Add(id)
+	}
+	if len(seen) != 100 {
+		t.Fatalf("read back %d IDs, want 100", len(seen))
+	}
+}
+
+func TestWriteBatchDelimiters(t *testing.T) {
+	var buf bytes.Buffer
+	if _, err := WriteBatch(&buf, "JNDE", 3, ','); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	// every ID, the last included, is followed by the delimiter
+	if len(out) != 3*(maxLen+1) || strings.Count(out, ",") != 3 || !strings.HasSuffix(out, ",") {
+		t.Fatalf("WriteBatch wrote %q", out)
+	}
+	for i := maxLen; i < len(out); i += maxLen + 1 {
+		if out[i] != ',' {
+			t.Fatalf("WriteBatch wrote %q, want a delimiter at %d", out, i)
+		}
+	}
+
+	if n, err := WriteBatch(&buf, "JNDE", 0, ','); err != nil || n != 0 {
+		t.Fatalf("WriteBatch of none = %d, %v", n, err)
+	}
+	if _, err := WriteBatch(&buf, "jnde", 1, ','); err == nil {
+		t.Fatal("WriteBatch accepted an invalid prefix")
+	}
+}
+
+func TestWriteBatchConfiguredKeyspace(t *testing.T) {
+	defer Configure()
+	// filling all 16 IDs takes about 54 draws, so allow well beyond that
+	if err := Configure(WithAlphabet("AB"), WithSuffixLength(4), WithMaxRetries(1000)); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if n, err := WriteBatch(&buf, "JNDE", 17, '\n'); !errors.Is(err, ErrExhausted) || n != 0 || buf.Len() != 0 {
+		t.Fatalf("WriteBatch of more IDs than the keyspace holds = %d, %v and wrote %q; want ErrExhausted and nothing written", n, err, buf.String())
+	}
+	if n, err := WriteBatch(&buf, "JNDE", 16, '\n'); err != nil || n != 16 {
+		t.Fatalf("WriteBatch of the whole keyspace = %d, %v", n, err)
+	}
+}
+
+func TestNewExcluding(t *testing.T) {
+	existing := make(Set)
+	for i := 0; i < 10; i++ {
+		existing.Add(MustNew("JNDE"))
+	}
+	id, err := NewExcluding("JNDE", existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if existing.Contains(id) {
+		t.Fatalf("NewExcluding returned existing ID %q", id)
+	}
+}
+
+func TestNewExcludingTinyKeyspace(t *testing.T) {
+	defer Configure()
+	if err := Configure(WithAlphabet("AB"), WithSuffixLength(4)); err != nil {
+		t.Fatal(err)
+	}
+
+	// all 16 IDs but two
+	existing := make(Set)
+	for n := 2; n < 16; n++ {
+		id := YULID{'J', 'N', 'D', 'E', '-'}
+		for i := range 4 {
+			id[prefixLen+separatorLen+i] = "AB"[n>>i&1]
+		}
+		existing.Add(id)
+	}
+	id, err := NewExcluding("JNDE", existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if existing.Contains(id) || (id.Suffix() != "AAAA" && id.Suffix() != "BAAA") {
+		t.Fatalf("NewExcluding = %q, want one of the two free IDs", id)
+	}
+
+	existing.Add(MustParse("JNDE-AAAA"))
+	existing.Add(MustParse("JNDE-BAAA"))
+	if _, err := NewExcluding("JNDE", existing); !errors.Is(err, ErrKeyspaceExhausted) {
+		t.Fatalf("NewExcluding over a full keyspace: err = %v, want ErrKeyspaceExhausted", err)
+	}
+}
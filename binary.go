@@ -0,0 +1,104 @@
+package yulid
+
+import (
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"strings"
+)
+
+// binaryLen is the size of the packed binary form
+const binaryLen = 8
+
+// Layout of the packed form, a big-endian uint64. The prefix and suffix are
+// base-36 numbers over the default alphabet, as in PackSuffix; 36^4 fits in 21
+// bits and 36^6 in 32. The present bit keeps the zero YULID, which encodes as
+// eight zero bytes, distinct from "AAAA-AAAA".
+const (
+	binarySuffixBits = 32
+	binaryPrefixBits = 21
+	binaryLenShift   = binarySuffixBits + binaryPrefixBits // two bits of suffix length minus minSuffixLen
+	binaryPresentBit = binaryLenShift + 2
+)
+
+var (
+	_ encoding.BinaryMarshaler   = YULID{}
+	_ encoding.BinaryUnmarshaler = (*YULID)(nil)
+
+	ErrInvalidBinary = errors.New("invalid packed YULID")
+)
+
+// Binary returns the compact 8-byte form of yd, for protobuf bytes fields and
+// binary caches. Only YULIDs in the standard format can be packed; see
+// MarshalBinary.
+func (yd YULID) Binary() ([]byte, error) {
+	return yd.MarshalBinary()
+}
+
+// FromBinary decodes the 8-byte form produced by Binary or MarshalBinary
+func FromBinary(b []byte) (YULID, error) {
+	var yd YULID
+	if err := yd.UnmarshalBinary(b); err != nil {
+		return YULID{}, err
+	}
+	return yd, nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, packing yd into 8 bytes.
+// yd must be a valid YULID in the standard format, with a '-' separator and an
+// A-Z0-9 suffix, or the zero value. The packed form is fixed, so this holds
+// even after Configure changes the default format. gob uses GobEncode
+// instead, which handles every format.
+func (yd YULID) MarshalBinary() ([]byte, error) {
+	b := make([]byte, binaryLen)
+	if yd.IsZero() {
+		return b, nil
+	}
+
+	suffix, ok := yd.PackSuffix()
+	if !ok {
+		return nil, standardGenerator.Validate(yd)
+	}
+	var prefix uint64
+	for _, c := range yd.Prefix() {
+		prefix = prefix*uint64(len(alphanumeric)) + uint64(strings.IndexRune(alphanumeric, c))
+	}
+
+	v := uint64(1)<<binaryPresentBit |
+		uint64(len(yd.Suffix())-minSuffixLen)<<binaryLenShift |
+		prefix<<binarySuffixBits |
+		suffix
+	binary.BigEndian.PutUint64(b, v)
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (yd *YULID) UnmarshalBinary(data []byte) error {
+	if len(data) != binaryLen {
+		return ErrInvalidBinary
+	}
+	v := binary.BigEndian.Uint64(data)
+	if v == 0 {
+		*yd = YULID{}
+		return nil
+	}
+	if v>>binaryPresentBit != 1 {
+		return ErrInvalidBinary
+	}
+
+	suffix, err := UnpackSuffix(v&(1<<binarySuffixBits-1), minSuffixLen+int(v>>binaryLenShift&3))
+	if err != nil {
+		return ErrInvalidBinary
+	}
+	prefix, err := unpackBase36(v>>binarySuffixBits&(1<<binaryPrefixBits-1), prefixLen)
+	if err != nil {
+		return ErrInvalidBinary
+	}
+
+	id, err := standardGenerator.Parse(prefix + "-" + suffix)
+	if err != nil {
+		return ErrInvalidBinary
+	}
+	*yd = id
+	return nil
+}
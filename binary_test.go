@@ -0,0 +1,82 @@
+package yulid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	for _, id := range []YULID{{}, MustParse("AAAA-AAAA"), MustParse("JNDE-AB12C"), MustParse("ZZZZ-ZZZZZZ")} {
+		b, err := id.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary(%q): %v", id, err)
+		}
+		if len(b) != binaryLen {
+			t.Fatalf("MarshalBinary(%q) is %d bytes, want %d", id, len(b), binaryLen)
+		}
+		got, err := FromBinary(b)
+		if err != nil {
+			t.Fatalf("FromBinary(%x): %v", b, err)
+		}
+		if got != id {
+			t.Fatalf("round trip of %q gave %q", id, got)
+		}
+	}
+}
+
+func TestMarshalBinaryNonDefaultFormat(t *testing.T) {
+	g, err := NewGenerator(WithSeparator('.'))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mustNew(t, g, "JNDE").MarshalBinary(); err == nil {
+		t.Fatal("MarshalBinary packed a YULID with a '.' separator")
+	}
+}
+
+func TestUnmarshalBinaryInvalid(t *testing.T) {
+	for _, b := range [][]byte{nil, make([]byte, 7), {0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}} {
+		if _, err := FromBinary(b); !errors.Is(err, ErrInvalidBinary) {
+			t.Errorf("FromBinary(%x): err = %v, want ErrInvalidBinary", b, err)
+		}
+	}
+}
+
+func TestBinaryIgnoresConfigure(t *testing.T) {
+	defer Configure()
+
+	for _, opts := range [][]Option{
+		{WithAlphabet("abcdefghijklmnopqrstuvwxyz")},
+		{WithSeparator('.')},
+	} {
+		if err := Configure(opts...); err != nil {
+			t.Fatal(err)
+		}
+
+		// the configured format cannot be packed
+		configured := MustNew("JNDE")
+		if n, ok := configured.PackSuffix(); ok {
+			t.Errorf("PackSuffix(%q) = %d under Configure, want false", configured, n)
+		}
+		if b, err := configured.MarshalBinary(); err == nil {
+			t.Errorf("MarshalBinary(%q) = %x under Configure, want an error", configured, b)
+		}
+
+		// standard IDs still round-trip
+		std, err := standardGenerator.New("JNDE")
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := std.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary(%q) under Configure: %v", std, err)
+		}
+		got, err := FromBinary(b)
+		if err != nil || got != std {
+			t.Fatalf("FromBinary(MarshalBinary(%q)) = %q, %v under Configure", std, got, err)
+		}
+		if _, ok := std.PackSuffix(); !ok {
+			t.Errorf("PackSuffix(%q) failed under Configure", std)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package yulid
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInvalidChecksum = errors.New("YULID check character does not match")
+)
+
+// WithChecksum makes the last suffix character a Luhn mod-N check character
+// over the rest of the suffix, where N is the alphabet size (36 by default).
+// It catches every single-character typo and most adjacent transpositions.
+//
+// The check character counts toward the suffix length, so a 6-character
+// suffix carries 5 random characters and the keyspace shrinks accordingly.
+// Validate verifies the check character when this option is given.
+func WithChecksum() Option {
+	return func(o *options) {
+		o.checksum = true
+	}
+}
+
+// ValidateChecksum checks id's format, as Validate does, and verifies that its
+// last suffix character is the Luhn mod-N check character of the others,
+// whether or not WithChecksum is among opts.
+func ValidateChecksum(id YULID, opts ...Option) error {
+	return Validate(id, append(opts, WithChecksum())...)
+}
+
+// checkChar returns the Luhn mod-N check character for data over alphabet
+func checkChar(alphabet string, data []byte) byte {
+	n := len(alphabet)
+	factor, sum := 2, 0
+	for i := len(data) - 1; i >= 0; i-- {
+		addend := factor * strings.IndexByte(alphabet, data[i])
+		sum += addend/n + addend%n
+		factor = 3 - factor
+	}
+	return alphabet[(n-sum%n)%n]
+}
+
+// validChecksum reports whether the last character of suffix is the check
+// character of the rest
+func validChecksum(alphabet string, suffix []byte) bool {
+	return len(suffix) > 1 && checkChar(alphabet, suffix[:len(suffix)-1]) == suffix[len(suffix)-1]
+}
+
+// CanonicalChecksummed returns the canonical form of yd followed by the Luhn
+// mod-N check character of its suffix, for printing and reading aloud. The
+// check character is not part of the stored ID, so the string is one
+// character longer than String: up to 12 characters for a 6-character suffix.
+// opts describe the format of yd as for ParseChecksummed, which reverses it
+// given the same opts, and the check character is computed over their
+// alphabet. The zero YULID has no suffix to check and returns "", as do
+// invalid opts.
+func (yd YULID) CanonicalChecksummed(opts ...Option) string {
+	g, err := generatorFor(opts)
+	if err != nil {
+		return ""
+	}
+	suffix := yd.SuffixBytes()
+	if len(suffix) == 0 {
+		return yd.String()
+	}
+	return yd.String() + string(checkChar(g.opts.alphabet, suffix))
+}
+
+// ParseChecksummed parses s as produced by CanonicalChecksummed: a YULID
+// followed by the check character of its suffix. It verifies the check
+// character and returns the YULID without it, so "JNDE-ED24HS" plus its
+// check character parses to the 11-character "JNDE-ED24HS". opts describe the
+// format of the stored ID as for Parse, and the check character is computed
+// over its alphabet.
+//
+// Whether the last character is data or a check character is decided by the
+// call, never by the input: Parse, with or without WithChecksum, treats every
+// character as part of the stored suffix, while ParseChecksummed always
+// strips exactly one.
+func ParseChecksummed(s string, opts ...Option) (YULID, error) {
+	g, err := generatorFor(opts)
+	if err != nil {
+		return YULID{}, err
+	}
+	if len(s) < minLen+1 || len(s) > maxLen+1 {
+		return YULID{}, ErrInvalidLength
+	}
+
+	id, err := g.Parse(s[:len(s)-1])
+	if err != nil {
+		return YULID{}, err
+	}
+	if c := s[len(s)-1]; checkChar(g.opts.alphabet, id.SuffixBytes()) != c {
+		return YULID{}, &ValidationError{Err: ErrInvalidChecksum, Index: len(s) - 1, Char: c}
+	}
+	return id, nil
+}
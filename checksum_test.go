@@ -0,0 +1,96 @@
+package yulid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWithChecksum(t *testing.T) {
+	g, err := NewGenerator(WithChecksum())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100; i++ {
+		id := mustNew(t, g, "JNDE")
+		if err := ValidateChecksum(id); err != nil {
+			t.Fatalf("ValidateChecksum(%q): %v", id, err)
+		}
+		s := id.String()
+		typo := replaceAt(s, 6, other(s[6]))
+		if err := g.Validate(MustParse(typo)); !errors.Is(err, ErrInvalidChecksum) {
+			t.Fatalf("Validate(%q): err = %v, want ErrInvalidChecksum", typo, err)
+		}
+	}
+}
+
+func TestChecksummedRoundTrip(t *testing.T) {
+	for _, id := range []YULID{MustParse("JNDE-ED24HS"), MustParse("JNDE-AB12C"), MustParse("JNDE-AB12"), MustNew("JNDE")} {
+		s := id.CanonicalChecksummed()
+		if len(s) != id.Len()+1 {
+			t.Fatalf("CanonicalChecksummed(%q) = %q, want one extra character", id, s)
+		}
+		got, err := ParseChecksummed(s)
+		if err != nil {
+			t.Fatalf("ParseChecksummed(%q): %v", s, err)
+		}
+		if got != id {
+			t.Fatalf("ParseChecksummed(%q) = %q, want %q", s, got, id)
+		}
+	}
+	if s := (YULID{}).CanonicalChecksummed(); s != "" {
+		t.Fatalf("zero CanonicalChecksummed = %q", s)
+	}
+}
+
+func TestChecksummedRoundTripAlphabet(t *testing.T) {
+	g, err := NewGenerator(WithAlphabet(AlphabetHumanSafe))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100; i++ {
+		id := mustNew(t, g, "JNDE")
+		s := id.CanonicalChecksummed(WithAlphabet(AlphabetHumanSafe))
+		if c := s[len(s)-1]; !strings.ContainsRune(AlphabetHumanSafe, rune(c)) {
+			t.Fatalf("CanonicalChecksummed(%q) = %q, check character outside the alphabet", id, s)
+		}
+		got, err := ParseChecksummed(s, WithAlphabet(AlphabetHumanSafe))
+		if err != nil {
+			t.Fatalf("ParseChecksummed(%q): %v", s, err)
+		}
+		if got != id {
+			t.Fatalf("ParseChecksummed(%q) = %q, want %q", s, got, id)
+		}
+	}
+	if s := MustParse("JNDE-AB12").CanonicalChecksummed(WithSeparator('A')); s != "" {
+		t.Fatalf("CanonicalChecksummed with invalid options = %q, want \"\"", s)
+	}
+}
+
+func TestParseChecksummedIsExplicit(t *testing.T) {
+	s := MustParse("JNDE-AB12C").CanonicalChecksummed()
+
+	// the same 11 characters are a plain 6-character suffix to Parse
+	plain, err := Parse(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plain.Len() != 11 {
+		t.Fatalf("Parse(%q) = %q", s, plain)
+	}
+	checked, err := ParseChecksummed(s)
+	if err != nil || checked.Len() != 10 {
+		t.Fatalf("ParseChecksummed(%q) = %q, %v", s, checked, err)
+	}
+
+	bad := replaceAt(s, len(s)-1, other(s[len(s)-1]))
+	var verr *ValidationError
+	if _, err := ParseChecksummed(bad); !errors.As(err, &verr) || !errors.Is(err, ErrInvalidChecksum) || verr.Index != len(s)-1 {
+		t.Fatalf("ParseChecksummed(%q): err = %v, want ErrInvalidChecksum at %d", bad, err, len(s)-1)
+	}
+	for _, in := range []string{"", "JNDE-AB1", "JNDE-ED24HS00"} {
+		if _, err := ParseChecksummed(in); !errors.Is(err, ErrInvalidLength) {
+			t.Errorf("ParseChecksummed(%q): err = %v, want ErrInvalidLength", in, err)
+		}
+	}
+}
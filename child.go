@@ -0,0 +1,82 @@
+package yulid
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+var (
+	ErrNoDepthMarker = errors.New("YULID format has no depth marker")
+)
+
+// WithDepthMarker reserves the last suffix character, before any check
+// character, as a generation marker for tree-structured IDs. Generator.New
+// writes roots at depth 0 and Generator.Child writes each child one deeper,
+// using the alphabet's characters in order, so trees can be up to
+// len(alphabet)-1 generations deep.
+//
+// The marker costs one random character: a 6-character suffix carries 5, and
+// its keyspace shrinks from 36^6 to 36^5 per prefix and depth. Every ID in
+// the format carries a marker, so Depth is only meaningful for IDs generated
+// with this option, and it is not part of the default format.
+func WithDepthMarker() Option {
+	return func(o *options) {
+		o.depth = true
+	}
+}
+
+// Child returns a new YULID under the same prefix as yd with a fresh,
+// independent suffix in the default format. It records no link to yd; use a
+// Generator with WithDepthMarker to mark children with their depth.
+func (yd YULID) Child() (YULID, error) {
+	if err := Validate(yd); err != nil {
+		return YULID{}, err
+	}
+	return defaultGenerator().New(yd.Prefix())
+}
+
+// Child returns a new YULID under the same prefix as parent, marked one
+// generation deeper. The Generator must use WithDepthMarker.
+func (g *Generator) Child(parent YULID) (YULID, error) {
+	depth, err := g.Depth(parent)
+	if err != nil {
+		return YULID{}, err
+	}
+	if depth+1 >= len(g.opts.alphabet) {
+		return YULID{}, errors.New("YULID is too deeply nested to derive a child")
+	}
+	return g.newAtDepth(context.Background(), parent.Prefix(), depth+1)
+}
+
+// Depth returns how many Child derivations separate id from its root. The
+// Generator must use WithDepthMarker, and id must be valid in its format.
+func (g *Generator) Depth(id YULID) (int, error) {
+	if !g.opts.depth {
+		return 0, ErrNoDepthMarker
+	}
+	if err := g.Validate(id); err != nil {
+		return 0, err
+	}
+	return strings.IndexByte(g.opts.alphabet, id[g.opts.depthPos(id)]), nil
+}
+
+// depthPos returns the index of id's depth marker, the last suffix character
+// before any check character
+func (o options) depthPos(id YULID) int {
+	pos := id.Len() - 1
+	if o.checksum {
+		pos--
+	}
+	return pos
+}
+
+// setDepth rewrites id's depth marker, and its check character to match
+func (o options) setDepth(id *YULID, depth int) {
+	pos := o.depthPos(*id)
+	id[pos] = o.alphabet[depth]
+	if o.checksum {
+		start := prefixLen + separatorLen
+		id[pos+1] = checkChar(o.alphabet, id[start:pos+1])
+	}
+}
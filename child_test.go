@@ -0,0 +1,70 @@
+package yulid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestChildKeepsPrefix(t *testing.T) {
+	parent := MustParse("JNDE-AB12CD")
+	child, err := parent.Child()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if child.Prefix() != parent.Prefix() {
+		t.Fatalf("child %q of %q has a different prefix", child, parent)
+	}
+	if err := Validate(child); err != nil {
+		t.Fatalf("child %q is invalid: %v", child, err)
+	}
+}
+
+func TestDepthMarker(t *testing.T) {
+	for name, opts := range map[string][]Option{
+		"plain":     {WithDepthMarker()},
+		"checksum":  {WithDepthMarker(), WithChecksum()},
+		"short":     {WithDepthMarker(), WithSuffixLength(4)},
+		"short-sum": {WithDepthMarker(), WithChecksum(), WithSuffixLength(4)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			g, err := NewGenerator(opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			id := mustNew(t, g, "JNDE")
+			for want := 0; want < 5; want++ {
+				if got, err := g.Depth(id); err != nil || got != want {
+					t.Fatalf("Depth(%q) = %d, %v; want %d", id, got, err, want)
+				}
+				child, err := g.Child(id)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if child.Prefix() != id.Prefix() {
+					t.Fatalf("child %q of %q has a different prefix", child, id)
+				}
+				id = child
+			}
+		})
+	}
+}
+
+func TestDepthRequiresMarker(t *testing.T) {
+	if _, err := defaultGenerator().Depth(MustParse("JNDE-AB12C")); !errors.Is(err, ErrNoDepthMarker) {
+		t.Fatalf("Depth without WithDepthMarker: err = %v, want ErrNoDepthMarker", err)
+	}
+}
+
+func TestChildTooDeep(t *testing.T) {
+	g, err := NewGenerator(WithDepthMarker(), WithAlphabet("AB"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	child, err := g.Child(mustNew(t, g, "JNDE"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.Child(child); err == nil {
+		t.Fatal("Child went deeper than the alphabet can mark")
+	}
+}
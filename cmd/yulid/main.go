@@ -0,0 +1,231 @@
+// Command yulid generates, validates and inspects YULIDs from the terminal.
+//
+// Usage:
+//
+//	yulid new --prefix JNDE [--count N] [--checksum] [--json]
+//	yulid validate [--checksum] [--json] <id>
+//	yulid inspect [--json] <id>
+//
+// validate exits with status 1 when the ID is invalid, and every subcommand
+// exits with status 2 on a usage error.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	yulid "github.com/mikills/yul_id"
+)
+
+const usage = `usage:
+  yulid new --prefix PREFIX [--count N] [--checksum] [--json]
+  yulid validate [--checksum] [--json] ID
+  yulid inspect [--json] ID
+`
+
+var (
+	// errUsage marks errors caused by bad command-line arguments
+	errUsage = errors.New("usage error")
+
+	// errInvalid reports that validate was given an invalid ID, after the
+	// result has already been printed
+	errInvalid = errors.New("invalid YULID")
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command line args and returns the process exit status
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	var err error
+	switch args[0] {
+	case "new":
+		err = runNew(args[1:], stdout)
+	case "validate":
+		err = runValidate(args[1:], stdout)
+	case "inspect":
+		err = runInspect(args[1:], stdout)
+	case "help", "-h", "--help":
+		fmt.Fprint(stdout, usage)
+		return 0
+	default:
+		err = fmt.Errorf("%w: unknown command %q", errUsage, args[0])
+	}
+
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, errUsage):
+		fmt.Fprintf(stderr, "yulid: %v\n%s", err, usage)
+		return 2
+	case errors.Is(err, errInvalid):
+		return 1
+	default:
+		fmt.Fprintf(stderr, "yulid: %v\n", err)
+		return 1
+	}
+}
+
+// newFlagSet returns a flag set for a subcommand that reports errors through run
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+// parseFlags parses args into fs and checks the number of positional arguments
+func parseFlags(fs *flag.FlagSet, args []string, positional int) error {
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if fs.NArg() != positional {
+		return fmt.Errorf("%w: %s takes %d argument(s)", errUsage, fs.Name(), positional)
+	}
+	return nil
+}
+
+func runNew(args []string, stdout io.Writer) error {
+	fs := newFlagSet("new")
+	prefix := fs.String("prefix", "", "4-character alphanumeric prefix")
+	count := fs.Int("count", 1, "number of IDs to generate")
+	checksum := fs.Bool("checksum", false, "append a check character")
+	asJSON := fs.Bool("json", false, "print a JSON array")
+	if err := parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+	if *prefix == "" {
+		return fmt.Errorf("%w: --prefix is required", errUsage)
+	}
+	if *count < 1 {
+		return fmt.Errorf("%w: --count must be at least 1", errUsage)
+	}
+
+	var opts []yulid.Option
+	if *checksum {
+		opts = append(opts, yulid.WithChecksum())
+	}
+	g, err := yulid.NewGenerator(opts...)
+	if err != nil {
+		return err
+	}
+	ids, err := g.NewBatch(*prefix, *count)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return json.NewEncoder(stdout).Encode(ids)
+	}
+	for _, id := range ids {
+		fmt.Fprintln(stdout, id)
+	}
+	return nil
+}
+
+// validateResult is the JSON output of validate. The components are set for a
+// valid ID, and Position, the index of the offending byte, for an invalid one
+// when the error has a single position.
+type validateResult struct {
+	ID       string `json:"id"`
+	Valid    bool   `json:"valid"`
+	Prefix   string `json:"prefix,omitempty"`
+	Suffix   string `json:"suffix,omitempty"`
+	Length   int    `json:"length,omitempty"`
+	Error    string `json:"error,omitempty"`
+	Position *int   `json:"position,omitempty"`
+}
+
+func runValidate(args []string, stdout io.Writer) error {
+	fs := newFlagSet("validate")
+	checksum := fs.Bool("checksum", false, "require a valid check character")
+	asJSON := fs.Bool("json", false, "print a JSON object")
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+
+	var opts []yulid.Option
+	if *checksum {
+		opts = append(opts, yulid.WithChecksum())
+	}
+	res := validateResult{ID: fs.Arg(0)}
+	id, err := yulid.Parse(fs.Arg(0), opts...)
+	if err == nil {
+		res.Valid = true
+		res.Prefix, res.Suffix, res.Length = id.Prefix(), id.Suffix(), id.Len()
+	} else {
+		res.Error = err.Error()
+		var verr *yulid.ValidationError
+		if errors.As(err, &verr) {
+			res.Position = &verr.Index
+		}
+	}
+
+	if *asJSON {
+		if err := json.NewEncoder(stdout).Encode(res); err != nil {
+			return err
+		}
+	} else if res.Valid {
+		fmt.Fprintf(stdout, "%s: valid\n", res.ID)
+	} else {
+		fmt.Fprintf(stdout, "%s: invalid: %s\n", res.ID, res.Error)
+	}
+
+	if !res.Valid {
+		return errInvalid
+	}
+	return nil
+}
+
+// inspectResult is the JSON output of inspect
+type inspectResult struct {
+	ID       string `json:"id"`
+	Prefix   string `json:"prefix"`
+	Suffix   string `json:"suffix"`
+	Length   int    `json:"length"`
+	Checksum bool   `json:"checksum"`
+}
+
+func runInspect(args []string, stdout io.Writer) error {
+	fs := newFlagSet("inspect")
+	asJSON := fs.Bool("json", false, "print a JSON object")
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+
+	id, err := yulid.Parse(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	parts, err := id.Parts()
+	if err != nil {
+		return err
+	}
+	res := inspectResult{
+		ID:       id.String(),
+		Prefix:   parts.Prefix,
+		Suffix:   parts.Suffix,
+		Length:   id.Len(),
+		Checksum: yulid.ValidateChecksum(id) == nil,
+	}
+
+	if *asJSON {
+		return json.NewEncoder(stdout).Encode(res)
+	}
+	checksum := "absent or invalid"
+	if res.Checksum {
+		checksum = "valid"
+	}
+	fmt.Fprintf(stdout, "id:       %s\nprefix:   %s\nsuffix:   %s\nlength:   %d\nchecksum: %s\n",
+		res.ID, res.Prefix, res.Suffix, res.Length, checksum)
+	return nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestValidateJSON(t *testing.T) {
+	tests := []struct {
+		id     string
+		status int
+		want   string
+	}{
+		{"JNDE-ED24HS", 0, `{"id":"JNDE-ED24HS","valid":true,"prefix":"JNDE","suffix":"ED24HS","length":11}`},
+		{"JNDE-ED24H!", 1, `{"id":"JNDE-ED24H!","valid":false,"error":"YULID random part contains invalid characters: '!' at index 10","position":10}`},
+		{"JN!E-ED24HS", 1, `{"id":"JN!E-ED24HS","valid":false,"error":"YULID has an invalid prefix: '!' at index 2","position":2}`},
+		{"JNDE", 1, `{"id":"JNDE","valid":false,"error":"YULID has an invalid length"}`},
+	}
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		if status := run([]string{"validate", "--json", tt.id}, &stdout, &stderr); status != tt.status {
+			t.Errorf("validate %q exited %d, want %d; stderr %q", tt.id, status, tt.status, stderr.String())
+		}
+		if got := strings.TrimSpace(stdout.String()); got != tt.want {
+			t.Errorf("validate %q printed\n%s\nwant\n%s", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestNewJSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if status := run([]string{"new", "--prefix", "JNDE", "--count", "3", "--json"}, &stdout, &stderr); status != 0 {
+		t.Fatalf("new exited %d: %s", status, stderr.String())
+	}
+	var ids []string
+	if err := json.Unmarshal(stdout.Bytes(), &ids); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 3 {
+		t.Fatalf("new printed %d IDs, want 3", len(ids))
+	}
+	for _, id := range ids {
+		if status := run([]string{"validate", id}, &bytes.Buffer{}, &stderr); status != 0 {
+			t.Errorf("generated %q does not validate", id)
+		}
+	}
+}
+
+func TestInspectJSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if status := run([]string{"inspect", "--json", "JNDE-ED24HS"}, &stdout, &stderr); status != 0 {
+		t.Fatalf("inspect exited %d: %s", status, stderr.String())
+	}
+	want := `{"id":"JNDE-ED24HS","prefix":"JNDE","suffix":"ED24HS","length":11,"checksum":false}`
+	if got := strings.TrimSpace(stdout.String()); got != want {
+		t.Fatalf("inspect printed\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestUsageErrors(t *testing.T) {
+	for _, args := range [][]string{nil, {"bogus"}, {"new"}, {"new", "--prefix", "JNDE", "--count", "0"}, {"validate"}} {
+		var stdout, stderr bytes.Buffer
+		if status := run(args, &stdout, &stderr); status != 2 {
+			t.Errorf("run(%q) exited %d, want 2", args, status)
+		}
+		if !strings.Contains(stderr.String(), "usage:") {
+			t.Errorf("run(%q) did not print usage to stderr", args)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package yulid
+
+import "math"
+
+// CollisionProbability estimates the chance that at least two of
+// existingCount IDs under one prefix share a suffix, when each suffix is
+// suffixLen characters drawn uniformly from alphabetSize characters. It uses
+// the birthday bound 1 - e^(-n(n-1)/2N) for a keyspace of N suffixes, which is
+// accurate whenever n is small relative to N. With checksums enabled, pass the
+// suffix length minus one, since the check character adds no entropy.
+func CollisionProbability(existingCount, suffixLen, alphabetSize int) float64 {
+	if existingCount < 2 {
+		return 0
+	}
+	keyspace := math.Pow(float64(alphabetSize), float64(suffixLen))
+	n := float64(existingCount)
+	if n > keyspace {
+		return 1
+	}
+	return -math.Expm1(-n * (n - 1) / (2 * keyspace))
+}
+
+// SafeCapacity returns the largest number of IDs per prefix whose
+// CollisionProbability stays at or below targetProbability, for suffixes of
+// suffixLen characters from alphabetSize characters. It is never more than the
+// keyspace itself and is capped at math.MaxInt.
+func SafeCapacity(targetProbability float64, suffixLen, alphabetSize int) int {
+	keyspace := math.Pow(float64(alphabetSize), float64(suffixLen))
+	switch {
+	case targetProbability <= 0 || keyspace < 1:
+		return min(1, int(keyspace))
+	case targetProbability >= 1:
+		return clampInt(keyspace)
+	}
+
+	// solve n(n-1)/2N = -ln(1-p) for n
+	bound := -math.Log1p(-targetProbability) * 2 * keyspace
+	n := math.Floor((1 + math.Sqrt(1+4*bound)) / 2)
+	return clampInt(min(n, keyspace))
+}
+
+// clampInt converts a non-negative float to an int, saturating at math.MaxInt
+func clampInt(f float64) int {
+	if f >= math.MaxInt {
+		return math.MaxInt
+	}
+	return int(f)
+}
@@ -0,0 +1,61 @@
+package yulid
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCollisionProbability(t *testing.T) {
+	tests := []struct {
+		name                   string
+		n, suffixLen, alphabet int
+		want                   float64
+	}{
+		// the birthday problem: 23 people over 365 days, by the bound
+		{"23 birthdays", 23, 1, 365, 0.5000017521827106},
+		{"22 birthdays", 22, 1, 365, 0.4689381107801478},
+		{"1000 standard IDs", 1000, 6, 36, 0.00022944080660117577},
+		{"one ID", 1, 6, 36, 0},
+		{"no IDs", 0, 6, 36, 0},
+		{"more IDs than suffixes", 37, 1, 36, 1},
+	}
+	for _, tt := range tests {
+		got := CollisionProbability(tt.n, tt.suffixLen, tt.alphabet)
+		if math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("%s: CollisionProbability(%d, %d, %d) = %v, want %v", tt.name, tt.n, tt.suffixLen, tt.alphabet, got, tt.want)
+		}
+	}
+}
+
+func TestSafeCapacity(t *testing.T) {
+	tests := []struct {
+		name                string
+		p                   float64
+		suffixLen, alphabet int
+		want                int
+	}{
+		// 23 birthdays already exceed even odds, by a hair
+		{"even odds over 365", 0.5, 1, 365, 22},
+		{"even odds, standard", 0.5, 6, 36, 54933},
+		{"one percent, standard", 0.01, 6, 36, 6615},
+		{"one in a million, standard", 1e-6, 6, 36, 66},
+		{"one percent, checksummed human-safe", 0.01, 5, 32, 821},
+		{"certainty", 1, 4, 36, 36 * 36 * 36 * 36},
+		{"zero risk", 0, 6, 36, 1},
+		{"saturated", 1, 20, 36, math.MaxInt},
+	}
+	for _, tt := range tests {
+		got := SafeCapacity(tt.p, tt.suffixLen, tt.alphabet)
+		if got != tt.want {
+			t.Errorf("%s: SafeCapacity(%v, %d, %d) = %d, want %d", tt.name, tt.p, tt.suffixLen, tt.alphabet, got, tt.want)
+		}
+		if tt.p > 0 && tt.p < 1 {
+			if p := CollisionProbability(got, tt.suffixLen, tt.alphabet); p > tt.p {
+				t.Errorf("%s: CollisionProbability at capacity %d = %v, above the target", tt.name, got, p)
+			}
+			if p := CollisionProbability(got+1, tt.suffixLen, tt.alphabet); p <= tt.p {
+				t.Errorf("%s: CollisionProbability at capacity %d + 1 = %v, still within the target", tt.name, got, p)
+			}
+		}
+	}
+}
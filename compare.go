@@ -0,0 +1,48 @@
+package yulid
+
+import (
+	"bytes"
+	"slices"
+)
+
+// Compare orders YULIDs by prefix, then by suffix, returning -1, 0 or +1.
+// Like Equal it ignores everything from the first zero byte on, so a shorter
+// suffix sorts before any longer suffix it begins, and stray bytes past the
+// padding never affect the result. IDs that differ only in their separator
+// are ordered by it, so Compare(a, b) == 0 exactly when a.Equal(b).
+func Compare(a, b YULID) int {
+	sa, sb := a.canonical(), b.canonical()
+	if c := bytes.Compare(sa[:min(len(sa), prefixLen)], sb[:min(len(sb), prefixLen)]); c != 0 {
+		return c
+	}
+	if c := bytes.Compare(suffixOf(sa), suffixOf(sb)); c != 0 {
+		return c
+	}
+	return bytes.Compare(sa, sb)
+}
+
+// Less reports whether yd sorts before other under Compare
+func (yd YULID) Less(other YULID) bool {
+	return Compare(yd, other) < 0
+}
+
+// SortSlice sorts ids in place in the order defined by Compare
+func SortSlice(ids []YULID) {
+	slices.SortFunc(ids, Compare)
+}
+
+// canonical returns the bytes of yd before the first zero byte
+func (yd *YULID) canonical() []byte {
+	if i := bytes.IndexByte(yd[:], 0); i >= 0 {
+		return yd[:i]
+	}
+	return yd[:]
+}
+
+// suffixOf returns the part of a canonical ID after the separator
+func suffixOf(s []byte) []byte {
+	if len(s) <= prefixLen+separatorLen {
+		return nil
+	}
+	return s[prefixLen+separatorLen:]
+}
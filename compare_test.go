@@ -0,0 +1,81 @@
+package yulid
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b YULID
+		want int
+	}{
+		{MustParse("JNDE-AB12CD"), MustParse("JNDE-AB12CD"), 0},
+		{MustParse("ACME-ZZZZZZ"), MustParse("JNDE-0000"), -1}, // prefix first
+		{MustParse("JNDE-AB12"), MustParse("JNDE-AB12CD"), -1}, // a suffix before its extensions
+		{MustParse("JNDE-AB12C"), MustParse("JNDE-AB12"), 1},
+		{MustParse("JNDE-AB13"), MustParse("JNDE-AB12CD"), 1}, // then by character, not length
+		{MustParse("JNDE-0ZZZZZ"), MustParse("JNDE-A000"), -1},
+		{MustParse("JNDE.AB12", WithSeparator('.')), MustParse("JNDE-AB12CD"), -1}, // suffix before separator
+		{MustParse("JNDE.AB12", WithSeparator('.')), MustParse("JNDE-AB12"), 1},    // '.' > '-'
+		{YULID{}, MustParse("AAAA-0000"), -1},
+		{YULID{}, YULID{}, 0},
+	}
+	for _, tt := range tests {
+		if got := Compare(tt.a, tt.b); got != tt.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := Compare(tt.b, tt.a); got != -tt.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", tt.b, tt.a, got, -tt.want)
+		}
+		if got := tt.a.Less(tt.b); got != (tt.want < 0) {
+			t.Errorf("%q.Less(%q) = %v", tt.a, tt.b, got)
+		}
+		if got := Compare(tt.a, tt.b) == 0; got != tt.a.Equal(tt.b) {
+			t.Errorf("Compare(%q, %q) == 0 is %v but Equal is %v", tt.a, tt.b, got, !got)
+		}
+	}
+}
+
+func TestCompareIgnoresPadding(t *testing.T) {
+	id := MustParse("JNDE-AB12")
+
+	// bytes after the first zero are not part of the ID
+	stray := id
+	stray[maxLen-1] = 'Z'
+	if Compare(id, stray) != 0 || id.Less(stray) || stray.Less(id) {
+		t.Errorf("Compare(%q, %q with a stray byte) = %d, want 0", id, stray, Compare(id, stray))
+	}
+
+	// a fully zero-padded prefix sorts before any real one
+	short := YULID{'J', 'N'}
+	if Compare(short, id) >= 0 {
+		t.Errorf("Compare(%q, %q) = %d, want -1", short, id, Compare(short, id))
+	}
+}
+
+func TestSortSlice(t *testing.T) {
+	ids := []YULID{
+		MustParse("JNDE-AB12CD"),
+		MustParse("ACME-ZZZZ"),
+		MustParse("JNDE-AB12"),
+		MustParse("JNDE-0000ZZ"),
+		MustParse("JNDE-AB12C"),
+		MustParse("JNDE-AB12CD"),
+		{},
+		MustParse("JNDE-AB13"),
+	}
+	SortSlice(ids)
+
+	var got []string
+	for _, id := range ids {
+		got = append(got, id.String())
+	}
+	want := []string{"", "ACME-ZZZZ", "JNDE-0000ZZ", "JNDE-AB12", "JNDE-AB12C", "JNDE-AB12CD", "JNDE-AB12CD", "JNDE-AB13"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("SortSlice = %q, want %q", got, want)
+	}
+	if !slices.IsSortedFunc(ids, Compare) {
+		t.Fatal("SortSlice result is not sorted under Compare")
+	}
+}
@@ -0,0 +1,104 @@
+package yulid
+
+import "fmt"
+
+// Components is the breakdown of a YULID into its parts
+type Components struct {
+	Prefix    string
+	Separator byte
+	Suffix    string
+}
+
+// Parts validates yd and returns its components. It is useful for values of
+// uncertain provenance, where a malformed array should be reported rather than
+// silently split.
+func (yd YULID) Parts() (Components, error) {
+	if err := Validate(yd); err != nil {
+		return Components{}, err
+	}
+
+	return Components{
+		Prefix:    yd.Prefix(),
+		Separator: yd[prefixLen],
+		Suffix:    yd.Suffix(),
+	}, nil
+}
+
+// StableKey returns the prefix and core suffix of yd, excluding any
+// format-specific trailing characters, for use as a lookup key that survives
+// format upgrades.
+//
+// A YULID carries no record of the format it was generated in, so
+// StableKey treats it as plain: the key is the prefix and the full suffix.
+// For IDs from a Generator with checksums use Generator.StableKey, which
+// drops the check character. The depth marker of WithDepthMarker is part of the
+// core suffix: it distinguishes children at different depths and is kept.
+func (yd YULID) StableKey() string {
+	return yd.Prefix() + yd.Suffix()
+}
+
+// StableKey returns the stable key of yd in the Generator's format: the prefix
+// and the suffix without its trailing check character when checksums are
+// enabled. A checksummed ID and a plain ID with the same random characters
+// share a key, so turning checksums on does not orphan stored records.
+func (g *Generator) StableKey(yd YULID) string {
+	suffix := yd.Suffix()
+	if g.opts.checksum && suffix != "" {
+		suffix = suffix[:len(suffix)-1]
+	}
+	return yd.Prefix() + suffix
+}
+
+// Plain returns yd reduced to the standard PREFIX-SUFFIX form, for systems
+// that do not understand the Generator's format. A check character and a
+// depth marker are removed and the separator becomes '-'; IDs from a format
+// versioned with WithFormatVersion reduce through a Generator pinned to that
+// version. The remaining characters must form a valid standard ID, so Plain
+// fails with ErrInvalidLength when stripping would leave fewer than four
+// suffix characters, and with ErrInvalidSuffix for alphabets outside A-Z and
+// 0-9. A reduced ID no longer carries what was stripped: it cannot be checked
+// or given a depth again.
+func (g *Generator) Plain(yd YULID) (YULID, error) {
+	if err := g.Validate(yd); err != nil {
+		return YULID{}, err
+	}
+
+	suffix := yd.SuffixBytes()
+	if g.opts.checksum {
+		suffix = suffix[:len(suffix)-1]
+	}
+	if g.opts.depth {
+		suffix = suffix[:len(suffix)-1]
+	}
+	if len(suffix) < minSuffixLen {
+		return YULID{}, fmt.Errorf("%w: the plain form would have a %d-character suffix", ErrInvalidLength, len(suffix))
+	}
+
+	var plain YULID
+	copy(plain[:prefixLen], yd[:prefixLen])
+	plain[prefixLen] = '-'
+	copy(plain[prefixLen+separatorLen:], suffix)
+	if err := standardGenerator.Validate(plain); err != nil {
+		return YULID{}, err
+	}
+	return plain, nil
+}
+
+// StringChecked returns the canonical string form of yd, or an error if the
+// receiver is malformed: invalid characters, a missing or misplaced separator,
+// or stray bytes after the zero padding, reported as a *ValidationError for
+// ErrInvalidLength at the first such byte. String remains the infallible
+// fmt.Stringer.
+func (yd YULID) StringChecked() (string, error) {
+	if err := Validate(yd); err != nil {
+		return "", err
+	}
+
+	s := yd.String()
+	for i, b := range yd[len(s):] {
+		if b != 0 {
+			return "", &ValidationError{Err: ErrInvalidLength, Index: len(s) + i, Char: b}
+		}
+	}
+	return s, nil
+}
@@ -0,0 +1,181 @@
+package yulid
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestStableKeyIgnoresChecksum(t *testing.T) {
+	entropy := bytes.Repeat([]byte{7, 19, 200, 3, 42}, 8)
+	plain, err := NewGenerator(WithSuffixLength(5), WithEntropy(bytes.NewReader(entropy)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checked, err := NewGenerator(WithChecksum(), WithEntropy(bytes.NewReader(entropy)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, b := mustNew(t, plain, "JNDE"), mustNew(t, checked, "JNDE")
+	if a.Suffix() != b.Suffix()[:5] {
+		t.Fatalf("%q and %q do not share random characters", a, b)
+	}
+	if ka, kb := plain.StableKey(a), checked.StableKey(b); ka != kb {
+		t.Fatalf("StableKey(%q) = %q but StableKey(%q) = %q", a, ka, b, kb)
+	}
+	if got, want := a.StableKey(), "JNDE"+a.Suffix(); got != want {
+		t.Fatalf("YULID.StableKey(%q) = %q, want %q", a, got, want)
+	}
+}
+
+func TestPartsRejectsMalformed(t *testing.T) {
+	valid := MustParse("JNDE-AB12CD")
+	corrupt := func(i int, b byte) YULID {
+		yd := valid
+		yd[i] = b
+		return yd
+	}
+
+	tests := []struct {
+		name string
+		yd   YULID
+		want error
+	}{
+		{"separator", corrupt(prefixLen, '_'), ErrInvalidSeparator},
+		{"prefix", corrupt(1, 'n'), ErrInvalidPrefix},
+		{"suffix", corrupt(7, '!'), ErrInvalidSuffix},
+		{"hole in suffix", corrupt(7, 0), nil},
+		{"non-ASCII", corrupt(8, 0xc3), ErrNonASCII},
+		{"zero", YULID{}, nil},
+	}
+	for _, tt := range tests {
+		c, err := tt.yd.Parts()
+		if err == nil {
+			t.Errorf("%s: Parts = %+v, want an error", tt.name, c)
+			continue
+		}
+		if tt.want != nil && !errors.Is(err, tt.want) {
+			t.Errorf("%s: Parts err = %v, want %v", tt.name, err, tt.want)
+		}
+		if c != (Components{}) {
+			t.Errorf("%s: Parts returned %+v alongside its error", tt.name, c)
+		}
+	}
+
+	c, err := valid.Parts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c != (Components{Prefix: "JNDE", Separator: '-', Suffix: "AB12CD"}) {
+		t.Fatalf("Parts = %+v", c)
+	}
+}
+
+func TestPlain(t *testing.T) {
+	checked, err := NewGenerator(WithChecksum())
+	if err != nil {
+		t.Fatal(err)
+	}
+	marked, err := NewGenerator(WithDepthMarker(), WithChecksum(), WithSeparator('.'))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		g        *Generator
+		stripped int // trailing suffix characters Plain drops
+	}{
+		{standardGenerator, 0},
+		{checked, 1},
+		{marked, 2},
+	}
+	for _, tt := range tests {
+		id := mustNew(t, tt.g, "JNDE")
+		plain, err := tt.g.Plain(id)
+		if err != nil {
+			t.Fatalf("Plain(%q): %v", id, err)
+		}
+		suffix := id.Suffix()
+		if want := "JNDE-" + suffix[:len(suffix)-tt.stripped]; plain.String() != want {
+			t.Fatalf("Plain(%q) = %q, want %q", id, plain, want)
+		}
+		if err := Validate(plain); err != nil {
+			t.Fatalf("Plain(%q) = %q, which is not a standard ID: %v", id, plain, err)
+		}
+	}
+
+	if _, err := checked.Plain(YULID{}); err == nil {
+		t.Fatal("Plain accepted the zero YULID")
+	}
+}
+
+func TestPlainVersioned(t *testing.T) {
+	const version = 90
+	if !slices.Contains(FormatVersions(), version) {
+		if err := RegisterFormatVersion(version, WithChecksum()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	g, err := NewGenerator(WithFormatVersion(version))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := mustNew(t, g, "JNDE")
+	if _, v, err := ParseVersioned(id.String()); err != nil || v != version {
+		t.Fatalf("ParseVersioned(%q) = %d, %v", id, v, err)
+	}
+	plain, err := g.Plain(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plain.String() != id.String()[:id.Len()-1] {
+		t.Fatalf("Plain(%q) = %q, want the check character dropped", id, plain)
+	}
+}
+
+func TestPlainTooShort(t *testing.T) {
+	g, err := NewGenerator(WithSuffixLength(5), WithChecksum(), WithDepthMarker())
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := mustNew(t, g, "JNDE")
+	if _, err := g.Plain(id); !errors.Is(err, ErrInvalidLength) {
+		t.Fatalf("Plain(%q): err = %v, want ErrInvalidLength", id, err)
+	}
+}
+
+func TestStringChecked(t *testing.T) {
+	id := MustParse("JNDE-AB12C")
+	if s, err := id.StringChecked(); err != nil || s != "JNDE-AB12C" {
+		t.Fatalf("StringChecked(%q) = %q, %v", id, s, err)
+	}
+
+	noSeparator := YULID{'J', 'N', 'D', 'E', 'A', 'B', '1', '2', 'C'}
+	misplaced := YULID{'J', 'N', 'D', '-', 'E', 'A', 'B', '1', '2'}
+	badChar := YULID{'J', 'N', 'D', 'E', '-', 'A', '?', '1', '2'}
+	trailing := MustParse("JNDE-AB12")
+	trailing[maxLen-1] = 'X' // after the zero padding
+	tests := []struct {
+		id   YULID
+		want error
+	}{
+		{noSeparator, ErrInvalidSeparator},
+		{misplaced, ErrInvalidPrefix},
+		{badChar, ErrInvalidSuffix},
+		{trailing, ErrInvalidLength},
+		{YULID{}, ErrInvalidLength},
+	}
+	for _, tt := range tests {
+		if s, err := tt.id.StringChecked(); !errors.Is(err, tt.want) {
+			t.Errorf("StringChecked(%q) = %q, %v; want %v", tt.id[:], s, err, tt.want)
+		}
+		_ = tt.id.String() // String stays infallible
+	}
+
+	var ve *ValidationError
+	if _, err := trailing.StringChecked(); !errors.As(err, &ve) || ve.Index != maxLen-1 || ve.Char != 'X' {
+		t.Errorf("StringChecked(%q): err = %v, want a *ValidationError at index %d", trailing[:], err, maxLen-1)
+	}
+}
@@ -0,0 +1,113 @@
+package yulid
+
+import (
+	"context"
+	"strings"
+)
+
+// SuggestCorrection repairs an ID that fails checksum validation, as when a
+// customer misreads one character over the phone. It tries every single
+// substitution and every swap of adjacent characters in the suffix, and
+// returns the most likely candidate with a valid check character. An input
+// that already validates is returned unchanged. opts describe the format as
+// for ValidateChecksum, which SuggestCorrection implies.
+//
+// The search is bounded to one edit, so it costs at most a few hundred
+// checks, and an input with two errors is not corrected. A single check
+// character does not pin the error down by itself: a substitution can usually
+// be undone at any position. Candidates are therefore ranked, with swaps and
+// substitutions between characters that are easily confused, such as 0 and
+// O or 5 and S, ahead of arbitrary substitutions, and a candidate is returned
+// only if no other one shares its rank. Configure a UniquenessChecker among
+// opts to narrow the candidates, and the input itself, to issued IDs; without
+// one, only confusable substitutions and swaps are usually corrected.
+func SuggestCorrection(s string, opts ...Option) (YULID, bool) {
+	g, err := NewGenerator(append(opts, WithChecksum())...)
+	if err != nil {
+		return YULID{}, false
+	}
+	if id, err := g.Parse(s); err == nil && g.issued(id) {
+		return id, true
+	}
+	if len(s) < minLen || len(s) > maxLen {
+		return YULID{}, false
+	}
+
+	var (
+		found   YULID
+		best, n int // the rank of found, and the candidates sharing it
+	)
+	try := func(candidate []byte, rank int) {
+		if rank < best {
+			return
+		}
+		id, err := g.ParseBytes(candidate)
+		if err != nil || !g.issued(id) {
+			return
+		}
+		switch {
+		case rank > best:
+			found, best, n = id, rank, 1
+		case id != found:
+			n++
+		}
+	}
+
+	b := []byte(s)
+	for i := prefixLen + separatorLen; i < len(b); i++ {
+		orig := b[i]
+		for j := 0; j < len(g.opts.alphabet); j++ {
+			if c := g.opts.alphabet[j]; c != orig {
+				b[i] = c
+				if confusable(orig, c) {
+					try(b, rankLikely)
+				} else {
+					try(b, rankSubstitution)
+				}
+			}
+		}
+		b[i] = orig
+
+		if i+1 < len(b) && b[i] != b[i+1] {
+			b[i], b[i+1] = b[i+1], b[i]
+			try(b, rankLikely)
+			b[i], b[i+1] = b[i+1], b[i]
+		}
+	}
+
+	if n != 1 {
+		return YULID{}, false
+	}
+	return found, true
+}
+
+// Ranks of SuggestCorrection candidates, by how likely the edit is to be the
+// mistake
+const (
+	rankSubstitution = 1 // any other character in place of one
+	rankLikely       = 2 // a confusable character, or an adjacent swap
+)
+
+// confusableGroups are sets of characters that are easily misread or misheard
+// as one another
+var confusableGroups = []string{"0ODQ", "1IL", "2Z", "5S", "6G", "8B", "UV", "MN"}
+
+// confusable reports whether a and b are in the same confusableGroups entry
+func confusable(a, b byte) bool {
+	for _, group := range confusableGroups {
+		if strings.IndexByte(group, a) >= 0 && strings.IndexByte(group, b) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// issued reports whether id is known to the Generator's UniquenessChecker, or
+// true if it has none
+func (g *Generator) issued(id YULID) bool {
+	if g.opts.checker == nil {
+		return true
+	}
+	ok, err := g.opts.checker.Exists(context.Background(), id)
+	return err == nil && ok
+}
@@ -0,0 +1,105 @@
+package yulid
+
+import "testing"
+
+func TestSuggestCorrection(t *testing.T) {
+	g, err := NewGenerator(WithChecksum())
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := NewMemoryStore()
+	for i := 0; i < 50; i++ {
+		store.Add(mustNew(t, g, "JNDE"))
+	}
+
+	for id := range store.ids {
+		s := id.String()
+
+		if got, ok := SuggestCorrection(s); !ok || got != id {
+			t.Fatalf("SuggestCorrection(%q) = %q, %v for a valid ID", s, got, ok)
+		}
+
+		typo := replaceAt(s, 7, other(s[7]))
+		if got, ok := SuggestCorrection(typo, WithUniquenessChecker(store)); !ok || got != id {
+			t.Errorf("SuggestCorrection(%q) = %q, %v; want %q", typo, got, ok, id)
+		}
+
+		if s[6] != s[7] {
+			swapped := s[:6] + s[7:8] + s[6:7] + s[8:]
+			if got, ok := SuggestCorrection(swapped, WithUniquenessChecker(store)); !ok || got != id {
+				t.Errorf("SuggestCorrection(%q) = %q, %v; want %q", swapped, got, ok, id)
+			}
+		}
+
+		twice := replaceAt(replaceAt(s, 5, other(s[5])), 8, other(s[8]))
+		if got, ok := SuggestCorrection(twice, WithUniquenessChecker(store)); ok {
+			t.Errorf("SuggestCorrection(%q) = %q for a two-character error", twice, got)
+		}
+	}
+}
+
+func TestSuggestCorrectionRejectsGarbage(t *testing.T) {
+	for _, s := range []string{"", "JNDE", "JN!E-AB12CD", "JNDE-AB12CD0"} {
+		if got, ok := SuggestCorrection(s); ok {
+			t.Errorf("SuggestCorrection(%q) = %q", s, got)
+		}
+	}
+}
+
+func replaceAt(s string, i int, c byte) string {
+	return s[:i] + string(c) + s[i+1:]
+}
+
+// other returns an alphanumeric character different from c
+func other(c byte) byte {
+	if c == 'Q' {
+		return 'R'
+	}
+	return 'Q'
+}
+
+func TestSuggestCorrectionWithoutChecker(t *testing.T) {
+	g, err := NewGenerator(WithChecksum())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tried, recovered int
+	for i := 0; i < 500; i++ {
+		id := mustNew(t, g, "JNDE")
+		s := id.String()
+
+		// misread the first suffix character that has a lookalike
+		var typo string
+		for j := prefixLen + separatorLen; j < len(s) && typo == ""; j++ {
+			for _, c := range []byte(alphanumeric) {
+				if c != s[j] && confusable(s[j], c) {
+					typo = replaceAt(s, j, c)
+					break
+				}
+			}
+		}
+		if typo == "" {
+			continue
+		}
+		tried++
+
+		got, ok := SuggestCorrection(typo)
+		if !ok {
+			continue
+		}
+		if got != id {
+			t.Fatalf("SuggestCorrection(%q) = %q, want %q", typo, got, id)
+		}
+		recovered++
+	}
+	if recovered < tried/2 {
+		t.Fatalf("recovered %d of %d confusable typos without a checker", recovered, tried)
+	}
+
+	// JNDE-AB12CU carries a valid check character; reading its 1 as an I
+	// leaves a single likely repair
+	if got, ok := SuggestCorrection("JNDE-ABI2CU"); !ok || got != MustParse("JNDE-AB12CU") {
+		t.Fatalf("SuggestCorrection(%q) = %q, %v; want JNDE-AB12CU", "JNDE-ABI2CU", got, ok)
+	}
+}
@@ -0,0 +1,154 @@
+package yulid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+)
+
+// source type tags, so a UUID and a number never hash the same input
+const (
+	deriveUUID   byte = 'U'
+	deriveUint64 byte = 'N'
+	deriveName   byte = 'S'
+	deriveSalt   byte = 'E' // precedes a salt, ahead of the tagged source
+)
+
+// WithDerivationKey sets the secret key FromUUID, FromUint64 and FromName hash
+// their input with. Without a key anyone can recompute the mapping from a known
+// input; with one, the YULID reveals nothing about the source. The key must stay
+// the same for the mapping to stay stable.
+func WithDerivationKey(key []byte) Option {
+	return func(o *options) {
+		o.derivationKey = key
+	}
+}
+
+// WithSalt mixes salt into every derivation, so environments sharing a
+// derivation key, such as staging and production, map the same source ID or
+// name to different YULIDs while each stays deterministic on its own. Without
+// a salt, derivation is unchanged. Unlike the key, the salt need not be
+// secret, but it too must stay the same for the mapping to stay stable.
+func WithSalt(salt []byte) Option {
+	return func(o *options) {
+		o.salt = salt
+	}
+}
+
+// FromUUID derives a YULID for prefix from u, so the same UUID always maps to
+// the same YULID under the same key and format options. The suffix is drawn
+// from an HMAC-SHA256 of u keyed by WithDerivationKey.
+//
+// Distinct UUIDs can map to the same YULID, with the probability given by
+// CollisionProbability. Derivation cannot retry, so the SuffixFilter and
+// UniquenessChecker options are not consulted; migrations should check
+// derived IDs for clashes before relying on them.
+func FromUUID(prefix string, u [16]byte, opts ...Option) (YULID, error) {
+	g, err := generatorFor(opts)
+	if err != nil {
+		return YULID{}, err
+	}
+	return g.FromUUID(prefix, u)
+}
+
+// FromUint64 is like FromUUID for numeric source IDs
+func FromUint64(prefix string, n uint64, opts ...Option) (YULID, error) {
+	g, err := generatorFor(opts)
+	if err != nil {
+		return YULID{}, err
+	}
+	return g.FromUint64(prefix, n)
+}
+
+// FromUUID derives a YULID in the Generator's format; see the package-level
+// FromUUID.
+func (g *Generator) FromUUID(prefix string, u [16]byte) (YULID, error) {
+	return g.derive(prefix, append([]byte{deriveUUID}, u[:]...))
+}
+
+// FromUint64 derives a YULID in the Generator's format; see the package-level
+// FromUint64.
+func (g *Generator) FromUint64(prefix string, n uint64) (YULID, error) {
+	return g.derive(prefix, binary.BigEndian.AppendUint64([]byte{deriveUint64}, n))
+}
+
+// FromName derives a YULID from fullName, the deterministic counterpart of
+// NewFromName: the prefix comes from PrefixFromName and the suffix from an
+// HMAC of the whole name, so the same name always maps to the same YULID under
+// the same key, salt and format options. Names sharing a prefix are told
+// apart only by the derived suffix, with the collision caveats of FromUUID.
+func FromName(fullName string, opts ...Option) (YULID, error) {
+	g, err := generatorFor(opts)
+	if err != nil {
+		return YULID{}, err
+	}
+	return g.FromName(fullName)
+}
+
+// FromName derives a YULID in the Generator's format; see the package-level
+// FromName.
+func (g *Generator) FromName(fullName string) (YULID, error) {
+	prefix, err := g.PrefixFromName(fullName)
+	if err != nil {
+		return YULID{}, err
+	}
+	return g.derive(prefix, append([]byte{deriveName}, fullName...))
+}
+
+// derive builds a YULID for prefix whose suffix is determined by source
+func (g *Generator) derive(prefix string, source []byte) (YULID, error) {
+	if err := g.checkPrefix(prefix); err != nil {
+		return YULID{}, err
+	}
+	if len(g.opts.salt) > 0 {
+		// length-prefix the salt so it cannot run into the source
+		salted := binary.BigEndian.AppendUint32([]byte{deriveSalt}, uint32(len(g.opts.salt)))
+		source = append(append(salted, g.opts.salt...), source...)
+	}
+
+	var id YULID
+	copy(id[:prefixLen], prefix)
+	id[prefixLen] = g.opts.separator
+
+	start := prefixLen + separatorLen
+	random := id[start : start+g.opts.randomLen()]
+	stream := &hmacStream{mac: hmac.New(sha256.New, g.opts.derivationKey), source: source}
+	if err := generateRandom(random, stream, g.opts.alphabet); err != nil {
+		return YULID{}, err
+	}
+	if g.opts.firstSet != "" {
+		if err := generateRandom(random[:1], stream, g.opts.firstSet); err != nil {
+			return YULID{}, err
+		}
+	}
+	g.opts.writeSuffix(&id, random)
+
+	return id, nil
+}
+
+// hmacStream is an endless deterministic byte stream: the concatenation of
+// HMAC(key, counter || source) for counter = 0, 1, 2, ...
+type hmacStream struct {
+	mac     hash.Hash
+	source  []byte
+	counter uint32
+	block   []byte
+}
+
+func (s *hmacStream) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(s.block) == 0 {
+			s.mac.Reset()
+			s.mac.Write(binary.BigEndian.AppendUint32(nil, s.counter))
+			s.mac.Write(s.source)
+			s.block = s.mac.Sum(nil)
+			s.counter++
+		}
+		c := copy(p[n:], s.block)
+		s.block = s.block[c:]
+		n += c
+	}
+	return n, nil
+}
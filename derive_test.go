@@ -0,0 +1,53 @@
+package yulid
+
+import "testing"
+
+func TestFromUUIDDeterministic(t *testing.T) {
+	u := [16]byte{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	a, err := FromUUID("JNDE", u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := FromUUID("JNDE", u)
+	if a != b {
+		t.Fatalf("FromUUID gave %q then %q", a, b)
+	}
+	keyed, _ := FromUUID("JNDE", u, WithDerivationKey([]byte("secret")))
+	if keyed == a {
+		t.Fatalf("the derivation key did not change %q", a)
+	}
+	n, _ := FromUint64("JNDE", 42)
+	if err := Validate(n); err != nil {
+		t.Fatalf("FromUint64 gave invalid %q: %v", n, err)
+	}
+}
+
+func TestWithSalt(t *testing.T) {
+	const name = "John Doe"
+	unsalted, err := FromName(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if empty, _ := FromName(name, WithSalt(nil)); empty != unsalted {
+		t.Fatalf("an empty salt changed %q to %q", unsalted, empty)
+	}
+
+	staging, _ := FromName(name, WithSalt([]byte("staging")))
+	production, _ := FromName(name, WithSalt([]byte("production")))
+	if staging == production || staging == unsalted || production == unsalted {
+		t.Fatalf("salts did not separate the mapping: %q, %q, %q", unsalted, staging, production)
+	}
+	if again, _ := FromName(name, WithSalt([]byte("staging"))); again != staging {
+		t.Fatalf("salted FromName gave %q then %q", staging, again)
+	}
+	if staging.Prefix() != "JNDE" || production.Prefix() != "JNDE" {
+		t.Fatalf("salt changed the prefix: %q, %q", staging, production)
+	}
+
+	u := [16]byte{1, 2, 3}
+	a, _ := FromUUID("JNDE", u, WithSalt([]byte("staging")))
+	b, _ := FromUUID("JNDE", u, WithSalt([]byte("production")))
+	if a == b {
+		t.Fatalf("salts did not separate FromUUID: %q", a)
+	}
+}
@@ -0,0 +1,108 @@
+package yulid
+
+import "fmt"
+
+// Short returns just the suffix of yd, for compact display where the prefix is
+// already implied by context.
+func (yd YULID) Short() string {
+	return yd.Suffix()
+}
+
+// ShortWithPrefixContext returns the shortest display form of each ID in ids
+// that still tells it apart from the others, by dropping the leading
+// characters every ID in the set shares. The same number of characters is
+// dropped from every ID, and at least one suffix character of the shortest ID
+// is always kept, so {JNDE-ABCD, JNDE-ABCDE} displays as "D" and "DE". IDs
+// are shown in full when the set spans several prefixes, and as Short when
+// only the prefix is shared or ids holds a single distinct value.
+func ShortWithPrefixContext(ids []YULID) map[YULID]string {
+	out := make(map[YULID]string, len(ids))
+	if len(ids) == 0 {
+		return out
+	}
+
+	// find the leading characters shared by every ID, and the shortest suffix
+	common := ids[0].String()
+	shortest := len(ids[0].Suffix())
+	distinct := false
+	for _, id := range ids[1:] {
+		s := id.String()
+		n := 0
+		for n < len(common) && n < len(s) && common[n] == s[n] {
+			n++
+		}
+		common = common[:n]
+		shortest = min(shortest, len(id.Suffix()))
+		distinct = distinct || id != ids[0]
+	}
+
+	if len(common) < prefixLen+separatorLen {
+		for _, id := range ids {
+			out[id] = id.String()
+		}
+		return out
+	}
+
+	drop := 0
+	if distinct {
+		drop = min(len(common)-prefixLen-separatorLen, shortest-1)
+	}
+	for _, id := range ids {
+		out[id] = id.Short()[drop:]
+	}
+
+	return out
+}
+
+// Padded returns the canonical form of yd right-padded with spaces to the
+// maximum YULID width, so IDs line up in columns. The stored value is unchanged.
+func (yd YULID) Padded() string {
+	return fmt.Sprintf("%-*s", maxLen, yd.String())
+}
+
+// MaskExcept returns the canonical form of yd with every character masked by
+// '*' except the separator and those at the given zero-based positions.
+// Positions outside the ID are ignored.
+func (yd YULID) MaskExcept(positions ...int) string {
+	s := []byte(yd.String())
+	visible := make([]bool, len(s))
+	for _, p := range positions {
+		if p >= 0 && p < len(s) {
+			visible[p] = true
+		}
+	}
+
+	for i := range s {
+		if i != prefixLen && !visible[i] {
+			s[i] = '*'
+		}
+	}
+	return string(s)
+}
+
+// redactVisible is the number of trailing suffix characters left visible by Masked
+const redactVisible = 2
+
+// Masked returns yd with all but the last two suffix characters hidden, e.g.
+// "JNDE-****HS", for logs and UIs that must not show full IDs. It is the form
+// YULIDs take when logged through log/slog.
+func (yd YULID) Masked() string {
+	return yd.MaskedCustom(true, redactVisible)
+}
+
+// MaskedCustom is like Masked but chooses whether the prefix stays visible and
+// how many trailing suffix characters are shown. visibleSuffixChars is clamped
+// to the suffix length.
+func (yd YULID) MaskedCustom(keepPrefix bool, visibleSuffixChars int) string {
+	n := yd.Len()
+	keep := min(max(visibleSuffixChars, 0), len(yd.SuffixBytes()))
+
+	var positions []int
+	if keepPrefix {
+		positions = append(positions, 0, 1, 2, 3)
+	}
+	for i := n - keep; i < n; i++ {
+		positions = append(positions, i)
+	}
+	return yd.MaskExcept(positions...)
+}
@@ -0,0 +1,78 @@
+package yulid
+
+import (
+	"maps"
+	"strings"
+	"testing"
+)
+
+func TestShortWithPrefixContext(t *testing.T) {
+	tests := []struct {
+		ids  []string
+		want map[string]string
+	}{
+		{nil, map[string]string{}},
+		{[]string{"JNDE-ABCDEF"}, map[string]string{"JNDE-ABCDEF": "ABCDEF"}},
+		{[]string{"JNDE-ABCD", "JNDE-ABCDE"}, map[string]string{"JNDE-ABCD": "D", "JNDE-ABCDE": "DE"}},
+		{[]string{"JNDE-ABC123", "JNDE-ABC456"}, map[string]string{"JNDE-ABC123": "123", "JNDE-ABC456": "456"}},
+		{[]string{"JNDE-ABCDEF", "JNDE-XYZ123"}, map[string]string{"JNDE-ABCDEF": "ABCDEF", "JNDE-XYZ123": "XYZ123"}},
+		{[]string{"JNDE-ABCDEF", "MSMT-ABCDEF"}, map[string]string{"JNDE-ABCDEF": "JNDE-ABCDEF", "MSMT-ABCDEF": "MSMT-ABCDEF"}},
+		{[]string{"JNDE-ABCDEF", "JNDE-ABCDEF"}, map[string]string{"JNDE-ABCDEF": "ABCDEF"}},
+	}
+	for _, tt := range tests {
+		var ids []YULID
+		for _, s := range tt.ids {
+			ids = append(ids, MustParse(s))
+		}
+		got := make(map[string]string)
+		for id, short := range ShortWithPrefixContext(ids) {
+			got[id.String()] = short
+		}
+		if !maps.Equal(got, tt.want) {
+			t.Errorf("ShortWithPrefixContext(%q) = %v, want %v", tt.ids, got, tt.want)
+		}
+	}
+}
+
+func TestPadded(t *testing.T) {
+	for _, s := range []string{"JNDE-AB12", "JNDE-AB12C", "JNDE-AB12CD"} {
+		id := MustParse(s)
+		p := id.Padded()
+		if len(p) != maxLen || strings.TrimRight(p, " ") != s {
+			t.Errorf("Padded(%q) = %q, want %q padded to %d", id, p, s, maxLen)
+		}
+		if id.String() != s {
+			t.Errorf("Padded changed the receiver to %q", id)
+		}
+		if got, err := ParseLenient(p); err != nil || got != id {
+			t.Errorf("ParseLenient(%q) = %q, %v, want %q", p, got, err, id)
+		}
+	}
+}
+
+func TestMaskExcept(t *testing.T) {
+	id := MustParse("JNDE-ED24HS")
+	tests := []struct {
+		positions []int
+		want      string
+	}{
+		{nil, "****-******"},
+		{[]int{9, 10}, "****-****HS"},
+		{[]int{0, 1, 2, 3}, "JNDE-******"},
+		{[]int{0, 6, 10}, "J***-*D***S"},
+		{[]int{4}, "****-******"},          // the separator is always shown
+		{[]int{-1, 11, 99}, "****-******"}, // out of range
+		{[]int{5, 5}, "****-E*****"},
+	}
+	for _, tt := range tests {
+		if got := id.MaskExcept(tt.positions...); got != tt.want {
+			t.Errorf("MaskExcept(%v) = %q, want %q", tt.positions, got, tt.want)
+		}
+	}
+	if got := MustParse("JNDE-AB12").MaskExcept(8, 9, 10); got != "****-***2" {
+		t.Errorf("MaskExcept on a short ID = %q", got)
+	}
+	if got := id.Masked(); got != "JNDE-****HS" {
+		t.Errorf("Masked = %q", got)
+	}
+}
@@ -0,0 +1,79 @@
+package yulid
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// Distance returns the number of single-character edits separating the
+// suffixes of a and b, counting insertions, deletions, substitutions and
+// swaps of adjacent characters as one edit each (optimal string alignment, the
+// restricted form of Damerau–Levenshtein distance). Prefixes are not compared;
+// use SameTenant to check that two IDs belong to the same owner.
+func Distance(a, b YULID) int {
+	return editDistance(a.SuffixBytes(), b.SuffixBytes())
+}
+
+// Suggest returns the candidates sharing input's prefix whose suffix is within
+// maxDistance edits of input's, nearest first and otherwise in candidate
+// order. It is meant for "did you mean" lookups, so input need not be a valid
+// YULID: it is trimmed and uppercased, its first four characters are taken as
+// the prefix and everything after the separator as the suffix.
+func Suggest(input string, candidates []YULID, maxDistance int) []YULID {
+	input = strings.ToUpper(strings.TrimSpace(input))
+	if len(input) < prefixLen+separatorLen {
+		return nil
+	}
+	prefix, suffix := input[:prefixLen], []byte(input[prefixLen+separatorLen:])
+
+	type match struct {
+		id       YULID
+		distance int
+	}
+	var matches []match
+	for _, c := range candidates {
+		if c.Prefix() != prefix {
+			continue
+		}
+		if d := editDistance(suffix, c.SuffixBytes()); d <= maxDistance {
+			matches = append(matches, match{c, d})
+		}
+	}
+	slices.SortStableFunc(matches, func(x, y match) int {
+		return cmp.Compare(x.distance, y.distance)
+	})
+
+	suggestions := make([]YULID, len(matches))
+	for i, m := range matches {
+		suggestions[i] = m.id
+	}
+	return suggestions
+}
+
+// editDistance is the optimal string alignment distance between a and b
+func editDistance(a, b []byte) int {
+	// rows i-2, i-1 and i of the edit matrix
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(b)]
+}
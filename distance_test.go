@@ -0,0 +1,86 @@
+package yulid
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"JNDE-AB12CD", "JNDE-AB12CD", 0},
+		{"JNDE-AB12CD", "ACME-AB12CD", 0}, // prefixes are not compared
+		{"JNDE-AB12CD", "JNDE-AB12CE", 1}, // substitution
+		{"JNDE-AB12CD", "JNDE-BA12CD", 1}, // adjacent swap
+		{"JNDE-AB12CD", "JNDE-AB12C", 1},  // deletion
+		{"JNDE-AB12", "JNDE-AB123", 1},    // insertion
+		{"JNDE-AB12CD", "JNDE-AB12", 2},
+		{"JNDE-AB12CD", "JNDE-BA21DC", 3},
+		{"JNDE-AB12CD", "JNDE-ZZZZZZ", 6},
+		{"JNDE-AB12", "JNDE-WXYZ99", 6},
+	}
+	for _, tt := range tests {
+		a, b := MustParse(tt.a), MustParse(tt.b)
+		if got := Distance(a, b); got != tt.want {
+			t.Errorf("Distance(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := Distance(b, a); got != tt.want {
+			t.Errorf("Distance(%s, %s) = %d, want %d", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestEditDistanceIsRestricted(t *testing.T) {
+	// optimal string alignment never edits a substring twice, so "CA" to
+	// "ABC" takes three edits rather than the two of unrestricted
+	// Damerau-Levenshtein
+	if got := editDistance([]byte("CA"), []byte("ABC")); got != 3 {
+		t.Errorf("editDistance(CA, ABC) = %d, want 3", got)
+	}
+	if got := editDistance(nil, []byte("ABCD")); got != 4 {
+		t.Errorf("editDistance of an empty string = %d, want 4", got)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	candidates := []YULID{
+		MustParse("JNDE-AB12CD"),
+		MustParse("JNDE-AB12CE"),
+		MustParse("ACME-AB12CD"),
+		MustParse("JNDE-BA12CD"),
+		MustParse("JNDE-ZZZZZZ"),
+		MustParse("JNDE-AB12"),
+	}
+
+	tests := []struct {
+		input       string
+		maxDistance int
+		want        []string
+	}{
+		{"JNDE-AB12CD", 0, []string{"JNDE-AB12CD"}},
+		{"  jnde-ab12cd\n", 0, []string{"JNDE-AB12CD"}},
+		// nearest first, ties in candidate order
+		{"JNDE-AB12CX", 1, []string{"JNDE-AB12CD", "JNDE-AB12CE"}},
+		{"JNDE-AB12CX", 2, []string{"JNDE-AB12CD", "JNDE-AB12CE", "JNDE-BA12CD", "JNDE-AB12"}},
+		{"JNDE-BA12CE", 1, []string{"JNDE-AB12CE", "JNDE-BA12CD"}},
+		// other tenants are never suggested
+		{"ACME-AB12CE", 1, []string{"ACME-AB12CD"}},
+		// the input need not be a valid YULID
+		{"JNDE_AB12C", 1, []string{"JNDE-AB12CD", "JNDE-AB12CE", "JNDE-AB12"}},
+		{"JNDE-AB1!CD", 1, []string{"JNDE-AB12CD"}},
+		{"JNDE-QQQQQQ", 2, nil},
+		{"JNDE", 6, nil},
+		{"", 6, nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, id := range Suggest(tt.input, candidates, tt.maxDistance) {
+			got = append(got, id.String())
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Suggest(%q, %d) = %q, want %q", tt.input, tt.maxDistance, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+// Package yulid generates and validates YULIDs, short human-readable
+// identifiers for Yul customers.
+//
+// A YULID is a 4-character alphanumeric prefix, a hyphen, and a 4-6 character
+// random alphanumeric suffix, for example "JNDE-ED24HS":
+//
+//	id, err := yulid.New("JNDE")
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(id) // JNDE-ED24HS
+//
+//	id, err = yulid.Parse("JNDE-ED24HS")
+//	err = yulid.Validate(id)
+//
+// The package is imported as
+//
+//	import yulid "github.com/mikills/yul_id"
+//
+// # Compatibility
+//
+// The exported API follows semantic versioning. Within a major version,
+// exported identifiers are not removed or changed incompatibly, the YULID
+// string format accepted by Parse and Validate is not narrowed, and IDs
+// generated by one release validate under every later release of the same
+// major version. Error values may gain more specific wrapped variants; compare
+// them with errors.Is rather than ==.
+package yulid
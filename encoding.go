@@ -0,0 +1,55 @@
+package yulid
+
+import (
+	"encoding"
+	"encoding/json"
+)
+
+var (
+	_ encoding.TextMarshaler   = YULID{}
+	_ encoding.TextUnmarshaler = (*YULID)(nil)
+	_ json.Marshaler           = YULID{}
+	_ json.Unmarshaler         = (*YULID)(nil)
+)
+
+// MarshalText implements encoding.TextMarshaler, encoding yd as its canonical
+// string form
+func (yd YULID) MarshalText() ([]byte, error) {
+	return []byte(yd.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The text must be a valid
+// YULID in the default format, or empty, which MarshalText produces for the
+// zero YULID and which decodes back to it. The same holds for JSON, YAML and
+// any other encoding going through text.
+func (yd *YULID) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*yd = YULID{}
+		return nil
+	}
+	id, err := ParseBytes(text)
+	if err != nil {
+		return err
+	}
+	*yd = id
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, encoding yd as a JSON string
+func (yd YULID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(yd.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The value must be a JSON string
+// holding a valid YULID; null leaves yd unchanged.
+func (yd *YULID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return yd.UnmarshalText([]byte(s))
+}
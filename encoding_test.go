@@ -0,0 +1,86 @@
+package yulid
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestTextRoundTrip(t *testing.T) {
+	for _, id := range []YULID{{}, MustParse("JNDE-AB12CD"), MustParse("JNDE-AB12")} {
+		text, err := id.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got YULID
+		if err := got.UnmarshalText(text); err != nil {
+			t.Fatalf("UnmarshalText(%q): %v", text, err)
+		}
+		if got != id {
+			t.Fatalf("round trip of %q gave %q", id, got)
+		}
+	}
+}
+
+func TestUnmarshalTextRejectsInvalid(t *testing.T) {
+	var id YULID
+	for _, text := range []string{"JNDE", "jnde-ab12cd", "JNDE-AB12CD0", "JNDE_AB12CD"} {
+		if err := id.UnmarshalText([]byte(text)); err == nil {
+			t.Errorf("UnmarshalText(%q) succeeded", text)
+		}
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	type customer struct {
+		ID     YULID  `json:"id"`
+		Parent *YULID `json:"parent,omitempty"`
+	}
+	in := customer{ID: MustParse("JNDE-ED24HS")}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"id":"JNDE-ED24HS"}`; string(data) != want {
+		t.Fatalf("Marshal = %s, want %s", data, want)
+	}
+	var out customer
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out != in {
+		t.Fatalf("Unmarshal = %+v, want %+v", out, in)
+	}
+	if err := json.Unmarshal([]byte(`{"id":"JNDE-ED24H!"}`), &out); err == nil {
+		t.Fatal("Unmarshal accepted an invalid YULID")
+	}
+}
+
+// TestTOMLRoundTrip checks the path TOML libraries such as BurntSushi/toml and
+// pelletier/go-toml take: a YULID is written as a basic string holding its
+// MarshalText form and read back through UnmarshalText.
+func TestTOMLRoundTrip(t *testing.T) {
+	id := MustParse("JNDE-ED24HS")
+	text, err := id.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := "customer = " + strconv.Quote(string(text)) + "\n"
+
+	value, ok := strings.CutPrefix(strings.TrimSpace(doc), "customer = ")
+	if !ok {
+		t.Fatalf("malformed document %q", doc)
+	}
+	s, err := strconv.Unquote(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got YULID
+	if err := got.UnmarshalText([]byte(s)); err != nil {
+		t.Fatal(err)
+	}
+	if got != id {
+		t.Fatalf("round trip of %q gave %q", id, got)
+	}
+}
@@ -0,0 +1,49 @@
+package yulid
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+// biasedReader is a broken entropy source whose bytes only take four values
+type biasedReader struct{ r *rand.Rand }
+
+func (b biasedReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = byte(b.r.IntN(4))
+	}
+	return len(p), nil
+}
+
+func TestMeasureEntropy(t *testing.T) {
+	const n = 20000
+	uniform, err := standardGenerator.NewBatch("JNDE", n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := NewGenerator(WithEntropy(biasedReader{rand.New(rand.NewPCG(1, 2))}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	biased := make([]YULID, n)
+	for i := range biased {
+		biased[i] = mustNew(t, g, "JNDE")
+	}
+
+	// six suffix positions; the fixed prefix and separator add nothing
+	ideal := 6 * math.Log2(36)
+	if got := MeasureEntropy(uniform); got < ideal-0.5 || got > ideal {
+		t.Errorf("uniform corpus measured %.2f bits, want close to %.2f", got, ideal)
+	}
+	// four equally likely characters per position
+	if got := MeasureEntropy(biased); math.Abs(got-6*2) > 0.1 {
+		t.Errorf("biased corpus measured %.2f bits, want close to 12", got)
+	}
+	if got := MeasureEntropy([]YULID{uniform[0], uniform[0], uniform[0]}); got != 0 {
+		t.Errorf("repeated ID measured %.2f bits, want 0", got)
+	}
+	if got := MeasureEntropy(nil); got != 0 {
+		t.Errorf("empty corpus measured %.2f bits, want 0", got)
+	}
+}
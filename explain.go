@@ -0,0 +1,44 @@
+package yulid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Explain describes, position by position, how got differs from want. It is
+// meant for support tooling comparing what a customer typed against a stored
+// ID. Positions are zero-based byte offsets; adjacent swaps are reported as a
+// single transposition and length differences are reported before the
+// per-position details.
+func Explain(got, want string) string {
+	if got == want {
+		return "no differences"
+	}
+
+	var diffs []string
+	if len(got) != len(want) {
+		diffs = append(diffs, fmt.Sprintf("length: got %d characters, expected %d", len(got), len(want)))
+	}
+
+	n := min(len(got), len(want))
+	for i := 0; i < n; i++ {
+		if got[i] == want[i] {
+			continue
+		}
+		if i+1 < n && got[i] == want[i+1] && got[i+1] == want[i] {
+			diffs = append(diffs, fmt.Sprintf("positions %d-%d: got %q, expected %q (transposed)", i, i+1, got[i:i+2], want[i:i+2]))
+			i++
+			continue
+		}
+		diffs = append(diffs, fmt.Sprintf("position %d: got %q, expected %q", i, got[i], want[i]))
+	}
+
+	switch {
+	case len(got) > n:
+		diffs = append(diffs, fmt.Sprintf("positions %d-%d: unexpected %q", n, len(got)-1, got[n:]))
+	case len(want) > n:
+		diffs = append(diffs, fmt.Sprintf("positions %d-%d: missing %q", n, len(want)-1, want[n:]))
+	}
+
+	return strings.Join(diffs, "; ")
+}
@@ -0,0 +1,21 @@
+package yulid
+
+import "testing"
+
+func TestExplain(t *testing.T) {
+	tests := []struct {
+		name, got, want, explain string
+	}{
+		{"equal", "JNDE-ED24HS", "JNDE-ED24HS", "no differences"},
+		{"substitution", "JNDE-0D24HS", "JNDE-OD24HS", `position 5: got '0', expected 'O'`},
+		{"transposition", "JNDE-DE24HS", "JNDE-ED24HS", `positions 5-6: got "DE", expected "ED" (transposed)`},
+		{"too long", "JNDE-ED24HSX", "JNDE-ED24HS", `length: got 12 characters, expected 11; positions 11-11: unexpected "X"`},
+		{"too short", "JNDE-ED24", "JNDE-ED24HS", `length: got 9 characters, expected 11; positions 9-10: missing "HS"`},
+		{"several", "JMDE-ED24H5", "JNDE-ED24HS", `position 1: got 'M', expected 'N'; position 10: got '5', expected 'S'`},
+	}
+	for _, tt := range tests {
+		if got := Explain(tt.got, tt.want); got != tt.explain {
+			t.Errorf("%s: Explain(%q, %q) = %q, want %q", tt.name, tt.got, tt.want, got, tt.explain)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package yulid
+
+import "strings"
+
+// SuffixFilter decides whether a generated suffix is unacceptable, for example
+// because it spells an offensive word on its own or together with the prefix.
+// A Generator configured WithSuffixFilter regenerates rejected candidates and
+// counts them in Stats.FilterRejections.
+type SuffixFilter interface {
+	Reject(prefix, suffix string) bool
+}
+
+// SuffixFilterFunc adapts a function to the SuffixFilter interface
+type SuffixFilterFunc func(prefix, suffix string) bool
+
+// Reject implements SuffixFilter
+func (f SuffixFilterFunc) Reject(prefix, suffix string) bool {
+	return f(prefix, suffix)
+}
+
+// WithSuffixFilter makes generation regenerate candidates that f rejects
+func WithSuffixFilter(f SuffixFilter) Option {
+	return func(o *options) {
+		o.filter = f
+	}
+}
+
+// WithValidator adds a business rule, f, checked after the structural
+// checks. Generation regenerates a candidate that f rejects, counting it in
+// Stats.ValidatorRejections, unless the error wraps ErrInvalidPrefix: the
+// prefix is fixed for the call, so such an error is returned immediately.
+// Validate and Parse apply f too, returning its error as is.
+func WithValidator(f func(YULID) error) Option {
+	return func(o *options) {
+		o.validator = f
+	}
+}
+
+// WordFilter is a SuffixFilter rejecting candidates that contain any of its
+// uppercase words, either in the suffix or spanning the prefix and suffix with
+// the separator removed. A word lying wholly inside the prefix does not count,
+// since regenerating the suffix cannot change it. Digits are also read as the
+// letters they resemble (0 as O, 1 as I, 3 as E, 4 as A, 5 as S, 7 as T), so
+// "SH1T" matches "SHIT".
+type WordFilter []string
+
+// Reject implements SuffixFilter
+func (w WordFilter) Reject(prefix, suffix string) bool {
+	s := prefix + suffix
+	for _, candidate := range []string{s, leet.Replace(s)} {
+		for _, word := range w {
+			if endsAfter(candidate, word, len(prefix)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// endsAfter reports whether word occurs in s at a position ending beyond
+// offset n
+func endsAfter(s, word string, n int) bool {
+	for from := 0; ; from++ {
+		i := strings.Index(s[from:], word)
+		if i < 0 {
+			return false
+		}
+		if from+i+len(word) > n {
+			return true
+		}
+		from += i
+	}
+}
+
+// leet reads digits as the letters they are commonly used for
+var leet = strings.NewReplacer("0", "O", "1", "I", "3", "E", "4", "A", "5", "S", "7", "T")
+
+// ProfanityFilter rejects suffixes containing common profanity and slurs in
+// English, Spanish, Portuguese, French, German, Italian and Dutch. Words that
+// are frequent innocent substrings are left out to keep the rejection rate low.
+var ProfanityFilter SuffixFilter = WordFilter{
+	// English
+	"FUCK", "SHIT", "CUNT", "COCK", "DICK", "PISS", "TWAT", "WANK", "SLUT",
+	"WHORE", "BITCH", "PORN", "RAPE", "NAZI", "ANUS", "ANAL", "TITS", "FAG",
+	"NIGG", "KKK", "PUSSY", "BOOB",
+	// Spanish and Portuguese
+	"PUTA", "PUTO", "MIERDA", "CULO", "POLLA", "VERGA", "CONO", "PORRA",
+	"MERDA", "BUCETA", "FODA",
+	// French
+	"MERDE", "PUTE", "SALOPE", "NIQUE",
+	// German
+	"FICK", "FOTZE", "ARSCH", "HURE", "WICHS",
+	// Italian
+	"CAZZO", "FIGA", "STRONZ", "TROIA",
+	// Dutch
+	"KUT", "LUL", "HOER",
+}
@@ -0,0 +1,89 @@
+package yulid
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+var errLeadingZero = errors.New("suffix must not start with 0")
+
+func noLeadingZero(id YULID) error {
+	if id.Suffix()[0] == '0' {
+		return errLeadingZero
+	}
+	return nil
+}
+
+func TestWithValidatorRegenerates(t *testing.T) {
+	// reject every suffix not starting with A-C to force regeneration; with
+	// 3 in 36 accepted the default budget would run out now and then
+	g, err := NewGenerator(WithMaxRetries(1000), WithValidator(func(id YULID) error {
+		if c := id.Suffix()[0]; c < 'A' || c > 'C' {
+			return errors.New("suffix must start with A-C")
+		}
+		return nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 50; i++ {
+		id := mustNew(t, g, "JNDE")
+		if c := id.Suffix()[0]; c < 'A' || c > 'C' {
+			t.Fatalf("New returned %q, which the validator rejects", id)
+		}
+	}
+	if g.Stats().ValidatorRejections == 0 {
+		t.Fatal("no rejections counted")
+	}
+}
+
+func TestWithValidatorFailsFastOnPrefix(t *testing.T) {
+	calls := 0
+	g, err := NewGenerator(WithValidator(func(id YULID) error {
+		calls++
+		if id.Prefix() != "JNDE" {
+			return fmt.Errorf("%w: %s is not a customer prefix", ErrInvalidPrefix, id.Prefix())
+		}
+		return nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.New("MSMT"); !errors.Is(err, ErrInvalidPrefix) {
+		t.Fatalf("New(MSMT): err = %v, want ErrInvalidPrefix", err)
+	}
+	if calls != 1 {
+		t.Fatalf("validator called %d times, want 1", calls)
+	}
+}
+
+func TestWithValidatorAppliesToValidate(t *testing.T) {
+	id := MustParse("JNDE-0BCDEF")
+	if err := Validate(id); err != nil {
+		t.Fatal(err)
+	}
+	if err := Validate(id, WithValidator(noLeadingZero)); !errors.Is(err, errLeadingZero) {
+		t.Fatalf("Validate: err = %v, want errLeadingZero", err)
+	}
+	if _, err := Parse("JNDE-0BCDEF", WithValidator(noLeadingZero)); !errors.Is(err, errLeadingZero) {
+		t.Fatalf("Parse: err = %v, want errLeadingZero", err)
+	}
+}
+
+func TestWordFilter(t *testing.T) {
+	f := WordFilter{"SHIT"}
+	for _, tt := range []struct {
+		prefix, suffix string
+		reject         bool
+	}{
+		{"JNDE", "SHIT00", true},
+		{"JNDE", "SH1T00", true},
+		{"XXSH", "IT0000", true},
+		{"JNDE", "AB12CD", false},
+	} {
+		if got := f.Reject(tt.prefix, tt.suffix); got != tt.reject {
+			t.Errorf("Reject(%q, %q) = %v, want %v", tt.prefix, tt.suffix, got, tt.reject)
+		}
+	}
+}
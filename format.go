@@ -0,0 +1,196 @@
+package yulid
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrInvalidSegment = errors.New("ID segment contains invalid characters")
+)
+
+// Segment describes one run of characters in a Format
+type Segment struct {
+	Len      int    // number of characters, or the most the last segment may have
+	MinLen   int    // fewest characters the last segment may have; 0 means exactly Len
+	Alphabet string // allowed characters; empty means AlphabetAlphanumeric
+	Fixed    bool   // supplied by the caller to New, like a prefix, rather than random
+}
+
+// Format is a compiled ID layout of segments joined by separators, for IDs
+// that do not follow the prefix-separator-suffix layout of YULID. IDs in a
+// Format are plain strings, since they need not fit in a YULID.
+//
+// The layout "JN-DE-24HS7", two caller-supplied letter pairs and five random
+// characters, is
+//
+//	f, err := yulid.NewFormat([]yulid.Segment{
+//		{Len: 2, Alphabet: yulid.AlphabetLetters, Fixed: true},
+//		{Len: 2, Alphabet: yulid.AlphabetLetters, Fixed: true},
+//		{Len: 5},
+//	}, "--")
+//	id, err := f.New("JN", "DE")
+//
+// and the standard YULID layout is {Len: 4, Fixed: true}, {Len: 6, MinLen: 4}
+// with separators "-".
+//
+// A Format is safe for concurrent use.
+type Format struct {
+	segments   []Segment
+	separators string
+	minLen     int
+	maxLen     int
+}
+
+// NewFormat compiles a layout of segments separated by separators, which must
+// hold one character for each gap between segments. Only the last segment may
+// have a variable length. Alphabets must be distinct printable ASCII
+// characters, and no separator may appear in the alphabets either side of it,
+// so that every ID has exactly one reading.
+func NewFormat(segments []Segment, separators string) (*Format, error) {
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("%w: format needs at least one segment", ErrInvalidOption)
+	}
+	if len(separators) != len(segments)-1 {
+		return nil, fmt.Errorf("%w: %d segments need %d separators, got %d", ErrInvalidOption, len(segments), len(segments)-1, len(separators))
+	}
+
+	f := &Format{segments: make([]Segment, len(segments)), separators: separators}
+	for i, seg := range segments {
+		if seg.Alphabet == "" {
+			seg.Alphabet = alphanumeric
+		}
+		if seg.MinLen == 0 {
+			seg.MinLen = seg.Len
+		}
+		switch {
+		case seg.Len < 1 || seg.MinLen < 1 || seg.MinLen > seg.Len:
+			return nil, fmt.Errorf("%w: segment %d has invalid length %d-%d", ErrInvalidOption, i, seg.MinLen, seg.Len)
+		case seg.MinLen != seg.Len && i != len(segments)-1:
+			return nil, fmt.Errorf("%w: only the last segment may vary in length", ErrInvalidOption)
+		case !seg.Fixed && len(seg.Alphabet) < 2:
+			return nil, fmt.Errorf("%w: random segment %d needs at least two characters", ErrInvalidOption, i)
+		}
+		for j := 0; j < len(seg.Alphabet); j++ {
+			c := seg.Alphabet[j]
+			if !isPrintableASCII(c) || strings.IndexByte(seg.Alphabet[j+1:], c) >= 0 {
+				return nil, fmt.Errorf("%w: segment %d alphabet character %q is repeated or unprintable", ErrInvalidOption, i, c)
+			}
+		}
+		f.segments[i] = seg
+		f.minLen += seg.MinLen
+		f.maxLen += seg.Len
+	}
+
+	for i := 0; i < len(separators); i++ {
+		sep := separators[i]
+		if !isPrintableASCII(sep) ||
+			strings.IndexByte(f.segments[i].Alphabet, sep) >= 0 ||
+			strings.IndexByte(f.segments[i+1].Alphabet, sep) >= 0 {
+			return nil, fmt.Errorf("%w: separator %q must be printable ASCII outside the adjacent alphabets", ErrInvalidOption, sep)
+		}
+	}
+	f.minLen += len(separators)
+	f.maxLen += len(separators)
+
+	return f, nil
+}
+
+// New generates an ID in the format. fixed supplies the Fixed segments in
+// order, each of which must fit its segment; the other segments are filled
+// with random characters from crypto/rand, at their full length.
+func (f *Format) New(fixed ...string) (string, error) {
+	var b strings.Builder
+	b.Grow(f.maxLen)
+
+	next := 0
+	for i, seg := range f.segments {
+		if i > 0 {
+			b.WriteByte(f.separators[i-1])
+		}
+		if !seg.Fixed {
+			random := make([]byte, seg.Len)
+			if err := generateRandom(random, nil, seg.Alphabet); err != nil {
+				return "", err
+			}
+			b.Write(random)
+			continue
+		}
+
+		if next == len(fixed) {
+			return "", fmt.Errorf("format has more fixed segments than the %d values given", len(fixed))
+		}
+		v := fixed[next]
+		next++
+		if len(v) < seg.MinLen || len(v) > seg.Len {
+			return "", fmt.Errorf("%w: segment %d must have %s characters", ErrInvalidLength, i, lengthRange(seg))
+		}
+		for j := 0; j < len(v); j++ {
+			if strings.IndexByte(seg.Alphabet, v[j]) < 0 {
+				return "", &ValidationError{Err: ErrInvalidSegment, Index: b.Len() + j, Char: v[j]}
+			}
+		}
+		b.WriteString(v)
+	}
+	if next != len(fixed) {
+		return "", fmt.Errorf("format has %d fixed segments, got %d values", next, len(fixed))
+	}
+
+	return b.String(), nil
+}
+
+// Validate checks that s is an ID in the format. Errors identify the first
+// offending byte as a *ValidationError wrapping ErrNonASCII, ErrInvalidSegment
+// or ErrInvalidSeparator, or are ErrInvalidLength.
+func (f *Format) Validate(s string) error {
+	_, err := f.Split(s)
+	return err
+}
+
+// Split validates s and returns its segments, without separators
+func (f *Format) Split(s string) ([]string, error) {
+	if len(s) < f.minLen || len(s) > f.maxLen {
+		return nil, ErrInvalidLength
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return nil, &ValidationError{Err: ErrNonASCII, Index: i, Char: s[i]}
+		}
+	}
+
+	parts := make([]string, len(f.segments))
+	pos := 0
+	for i, seg := range f.segments {
+		if i > 0 {
+			if s[pos] != f.separators[i-1] {
+				return nil, &ValidationError{Err: ErrInvalidSeparator, Index: pos, Char: s[pos]}
+			}
+			pos++
+		}
+
+		// only the last segment varies, so it takes whatever remains
+		end := pos + seg.Len
+		if i == len(f.segments)-1 {
+			end = len(s)
+		}
+		for j := pos; j < end; j++ {
+			if strings.IndexByte(seg.Alphabet, s[j]) < 0 {
+				return nil, &ValidationError{Err: ErrInvalidSegment, Index: j, Char: s[j]}
+			}
+		}
+		parts[i] = s[pos:end]
+		pos = end
+	}
+
+	return parts, nil
+}
+
+// lengthRange describes the accepted length of seg for error messages
+func lengthRange(seg Segment) string {
+	if seg.MinLen == seg.Len {
+		return fmt.Sprint(seg.Len)
+	}
+	return fmt.Sprintf("%d-%d", seg.MinLen, seg.Len)
+}
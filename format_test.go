@@ -0,0 +1,149 @@
+package yulid
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestFormatRoundTrip(t *testing.T) {
+	f, err := NewFormat([]Segment{
+		{Len: 2, Alphabet: AlphabetLetters, Fixed: true},
+		{Len: 2, Alphabet: AlphabetLetters, Fixed: true},
+		{Len: 5},
+	}, "--")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 100; i++ {
+		id, err := f.New("JN", "DE")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(id) != 11 || !strings.HasPrefix(id, "JN-DE-") {
+			t.Fatalf("New = %q, want JN-DE- and five characters", id)
+		}
+		parts, err := f.Split(id)
+		if err != nil {
+			t.Fatalf("Split(%q): %v", id, err)
+		}
+		if !slices.Equal(parts[:2], []string{"JN", "DE"}) || len(parts[2]) != 5 {
+			t.Fatalf("Split(%q) = %q", id, parts)
+		}
+		if got := strings.Join(parts, "-"); got != id {
+			t.Fatalf("joined segments %q, want %q", got, id)
+		}
+	}
+}
+
+func TestFormatStandardLayout(t *testing.T) {
+	f, err := NewFormat([]Segment{{Len: 4, Fixed: true}, {Len: 6, MinLen: 4}}, "-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"JNDE-AB12", "JNDE-AB12C", "JNDE-AB12CD", MustNew("JNDE").String()} {
+		if err := f.Validate(s); err != nil {
+			t.Errorf("Validate(%q): %v", s, err)
+		}
+		if err := Validate(MustParse(s)); err != nil {
+			t.Errorf("%q is not a YULID: %v", s, err)
+		}
+	}
+
+	id, err := f.New("JNDE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Parse(id); err != nil {
+		t.Errorf("Format ID %q does not parse as a YULID: %v", id, err)
+	}
+}
+
+func TestFormatValidateErrors(t *testing.T) {
+	f, err := NewFormat([]Segment{
+		{Len: 3, Alphabet: AlphabetDigits, Fixed: true},
+		{Len: 4, MinLen: 2, Alphabet: AlphabetLetters},
+	}, "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		s     string
+		want  error
+		index int
+	}{
+		{"123/AB", nil, 0},
+		{"123/ABCD", nil, 0},
+		{"123/A", ErrInvalidLength, 0},
+		{"123/ABCDE", ErrInvalidLength, 0},
+		{"123-ABCD", ErrInvalidSeparator, 3},
+		{"12X/ABCD", ErrInvalidSegment, 2},
+		{"123/AB1D", ErrInvalidSegment, 6},
+		{"123/AB\xc3\xa9", ErrNonASCII, 6},
+	}
+	for _, tt := range tests {
+		err := f.Validate(tt.s)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("Validate(%q): err = %v, want %v", tt.s, err, tt.want)
+			continue
+		}
+		var ve *ValidationError
+		if errors.As(err, &ve) && ve.Index != tt.index {
+			t.Errorf("Validate(%q): error at %d, want %d", tt.s, ve.Index, tt.index)
+		}
+	}
+}
+
+func TestFormatNewErrors(t *testing.T) {
+	f, err := NewFormat([]Segment{{Len: 2, Alphabet: AlphabetLetters, Fixed: true}, {Len: 4}}, "-")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := f.New(); err == nil {
+		t.Error("New accepted too few fixed values")
+	}
+	if _, err := f.New("JN", "DE"); err == nil {
+		t.Error("New accepted too many fixed values")
+	}
+	if _, err := f.New("JND"); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("New with a long fixed value: err = %v, want ErrInvalidLength", err)
+	}
+	var ve *ValidationError
+	if _, err := f.New("J1"); !errors.As(err, &ve) || !errors.Is(err, ErrInvalidSegment) || ve.Index != 1 {
+		t.Errorf("New with a digit in a letter segment: err = %v, want ErrInvalidSegment at 1", err)
+	}
+}
+
+func TestNewFormatRejectsInvalid(t *testing.T) {
+	tests := []struct {
+		name       string
+		segments   []Segment
+		separators string
+	}{
+		{"no segments", nil, ""},
+		{"missing separator", []Segment{{Len: 2}, {Len: 2}}, ""},
+		{"extra separator", []Segment{{Len: 2}}, "-"},
+		{"zero length", []Segment{{Len: 0}}, ""},
+		{"minimum above length", []Segment{{Len: 2, MinLen: 3}}, ""},
+		{"variable middle segment", []Segment{{Len: 4, MinLen: 2}, {Len: 2}}, "-"},
+		{"one-character random alphabet", []Segment{{Len: 2, Alphabet: "A"}}, ""},
+		{"repeated alphabet character", []Segment{{Len: 2, Alphabet: "ABA"}}, ""},
+		{"unprintable alphabet character", []Segment{{Len: 2, Alphabet: "AB\x00"}}, ""},
+		{"separator in an alphabet", []Segment{{Len: 2}, {Len: 2, Alphabet: "AB-"}}, "-"},
+		{"unprintable separator", []Segment{{Len: 2}, {Len: 2}}, "\t"},
+	}
+	for _, tt := range tests {
+		if _, err := NewFormat(tt.segments, tt.separators); !errors.Is(err, ErrInvalidOption) {
+			t.Errorf("%s: err = %v, want ErrInvalidOption", tt.name, err)
+		}
+	}
+
+	// a fixed segment may use a single-character alphabet
+	if _, err := NewFormat([]Segment{{Len: 1, Alphabet: "X", Fixed: true}, {Len: 4}}, "-"); err != nil {
+		t.Errorf("NewFormat with a one-character fixed alphabet: %v", err)
+	}
+}
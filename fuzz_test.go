@@ -0,0 +1,65 @@
+package yulid
+
+import (
+	"bytes"
+	"testing"
+)
+
+// The seed corpus for each target lives in testdata/fuzz/<target>.
+
+// FuzzParse checks that Parse never panics, and that whatever it accepts is
+// valid and formats back to exactly the input, so nothing is silently
+// truncated or rewritten.
+func FuzzParse(f *testing.F) {
+	f.Fuzz(func(t *testing.T, s string) {
+		id, err := Parse(s)
+		if err != nil {
+			return
+		}
+		if got := id.String(); got != s {
+			t.Fatalf("Parse(%q).String() = %q", s, got)
+		}
+		if err := Validate(id); err != nil {
+			t.Fatalf("Parse(%q) returned %q, which fails Validate: %v", s, id, err)
+		}
+	})
+}
+
+// FuzzValidate checks that Validate never panics on arbitrary arrays, and that
+// any array it accepts parses back from its string form to an equal YULID.
+// Bytes after the first zero are padding, which Validate ignores, so the
+// comparison uses Equal rather than ==.
+func FuzzValidate(f *testing.F) {
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var id YULID
+		copy(id[:], b)
+		if err := Validate(id); err != nil {
+			return
+		}
+		got, err := Parse(id.String())
+		if err != nil {
+			t.Fatalf("Validate accepted %q, which fails Parse: %v", id, err)
+		}
+		if !got.Equal(id) {
+			t.Fatalf("Validate accepted %x, which parses back as %x", id, got)
+		}
+	})
+}
+
+// FuzzUnmarshalText checks that UnmarshalText never panics and that anything
+// it accepts marshals back to the same text.
+func FuzzUnmarshalText(f *testing.F) {
+	f.Fuzz(func(t *testing.T, text []byte) {
+		var id YULID
+		if err := id.UnmarshalText(text); err != nil {
+			return
+		}
+		got, err := id.MarshalText()
+		if err != nil {
+			t.Fatalf("MarshalText after UnmarshalText(%q): %v", text, err)
+		}
+		if !bytes.Equal(got, text) {
+			t.Fatalf("UnmarshalText(%q) marshals back as %q", text, got)
+		}
+	})
+}
@@ -0,0 +1,353 @@
+package yulid
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Generator creates and validates YULIDs in one configured format. The
+// package-level New, Parse and Validate use a default Generator with the
+// standard format and crypto/rand entropy, unless Configure replaces it.
+//
+// A Generator is safe for concurrent use as long as its entropy source is.
+type Generator struct {
+	opts options
+
+	generated           atomic.Uint64
+	filterRejections    atomic.Uint64
+	validatorRejections atomic.Uint64
+	collisions          atomic.Uint64
+}
+
+// Stats counts a Generator's activity since it was created
+type Stats struct {
+	Generated           uint64 // IDs returned to callers
+	FilterRejections    uint64 // candidates regenerated because the SuffixFilter rejected them
+	ValidatorRejections uint64 // candidates regenerated because the WithValidator hook rejected them
+	Collisions          uint64 // candidates regenerated because the UniquenessChecker reported them taken
+}
+
+// Stats returns the Generator's counters, so callers can monitor how often
+// candidates are regenerated.
+func (g *Generator) Stats() Stats {
+	return Stats{
+		Generated:           g.generated.Load(),
+		FilterRejections:    g.filterRejections.Load(),
+		ValidatorRejections: g.validatorRejections.Load(),
+		Collisions:          g.collisions.Load(),
+	}
+}
+
+// standardGenerator generates the standard format, which the package-level
+// functions use until Configure replaces it
+var standardGenerator = &Generator{opts: defaultOptions()}
+
+// configured is the Generator set by Configure, or nil
+var configured atomic.Pointer[Generator]
+
+// defaultGenerator returns the Generator backing the package-level functions
+func defaultGenerator() *Generator {
+	if g := configured.Load(); g != nil {
+		return g
+	}
+	return standardGenerator
+}
+
+// Configure replaces the default Generator behind the package-level New,
+// Parse, Validate and related functions with one for the format described by
+// opts, so an application can set its format once at startup. Invalid options
+// return an error and leave the current default in place; no options restore
+// the standard format. The replacement is atomic, but calls already running
+// finish with the old Generator, and IDs generated before a change may no
+// longer validate after it, so Configure should be called before the
+// package is used concurrently rather than mid-flight.
+func Configure(opts ...Option) error {
+	if len(opts) == 0 {
+		configured.Store(nil)
+		return nil
+	}
+	g, err := NewGenerator(opts...)
+	if err != nil {
+		return err
+	}
+	configured.Store(g)
+	return nil
+}
+
+// NewGenerator returns a Generator for the format described by opts
+func NewGenerator(opts ...Option) (*Generator, error) {
+	o, err := resolveOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Generator{opts: o}, nil
+}
+
+// generatorFor returns the default Generator when opts is empty, and a
+// Generator configured by opts otherwise
+func generatorFor(opts []Option) (*Generator, error) {
+	if len(opts) == 0 {
+		return defaultGenerator(), nil
+	}
+	return NewGenerator(opts...)
+}
+
+// New generates a YULID with the given 4-character alphanumeric prefix and a
+// random suffix in the Generator's format. It is NewContext with a background
+// context.
+func (g *Generator) New(prefix string) (YULID, error) {
+	return g.NewContext(context.Background(), prefix)
+}
+
+// NewContext is like New, passing ctx to the Generator's UniquenessChecker.
+// Candidates rejected by the SuffixFilter or reported as taken by the checker
+// are regenerated, up to the retry limit, after which ErrExhausted is returned.
+func (g *Generator) NewContext(ctx context.Context, prefix string) (YULID, error) {
+	if g.opts.observer == nil {
+		return g.newContext(ctx, prefix)
+	}
+	start := time.Now()
+	id, err := g.newContext(ctx, prefix)
+	g.observe(prefix, start, 1, err)
+	return id, err
+}
+
+func (g *Generator) newContext(ctx context.Context, prefix string) (YULID, error) {
+	return g.newAtDepth(ctx, prefix, 0)
+}
+
+// newAtDepth generates an ID for prefix carrying depth in its depth marker,
+// which must be 0 unless the format has one
+func (g *Generator) newAtDepth(ctx context.Context, prefix string, depth int) (YULID, error) {
+	if err := g.checkPrefix(prefix); err != nil {
+		return YULID{}, err
+	}
+
+	for attempt := 0; attempt <= g.opts.maxRetries(); attempt++ {
+		id, err := g.generate(prefix)
+		if err != nil {
+			return YULID{}, err
+		}
+		if depth > 0 {
+			g.opts.setDepth(&id, depth)
+		}
+		ok, err := g.accept(ctx, id)
+		if err != nil {
+			return YULID{}, err
+		}
+		if ok {
+			g.generated.Add(1)
+			return id, nil
+		}
+	}
+
+	return YULID{}, ErrExhausted
+}
+
+// accept runs a candidate through the SuffixFilter and the repeat check, the
+// validator hook and the UniquenessChecker, counting rejections
+func (g *Generator) accept(ctx context.Context, id YULID) (bool, error) {
+	if (g.opts.filter != nil && g.opts.filter.Reject(id.Prefix(), id.Suffix())) ||
+		(g.opts.noRepeats && adjacentRepeat(id.SuffixBytes()) >= 0) {
+		g.filterRejections.Add(1)
+		g.retried(id.Prefix(), RetryFiltered)
+		return false, nil
+	}
+	if g.opts.validator != nil {
+		if err := g.opts.validator(id); err != nil {
+			if errors.Is(err, ErrInvalidPrefix) {
+				return false, err
+			}
+			g.validatorRejections.Add(1)
+			g.retried(id.Prefix(), RetryRejected)
+			return false, nil
+		}
+	}
+	if g.opts.checker != nil {
+		exists, err := g.opts.checker.Exists(ctx, id)
+		if err != nil {
+			return false, err
+		}
+		if exists {
+			g.collisions.Add(1)
+			g.retried(id.Prefix(), RetryCollision)
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// checkPrefix checks that prefix is well formed, permitted by the allowlist
+// and not reserved
+func (g *Generator) checkPrefix(prefix string) error {
+	if err := validatePrefix(prefix); err != nil {
+		return err
+	}
+	if !prefixAllowed(prefix) {
+		return ErrPrefixNotAllowed
+	}
+	if _, ok := g.opts.reserved[prefix]; ok {
+		return ErrReservedPrefix
+	}
+	return nil
+}
+
+// generate writes prefix, separator and a fresh random suffix into a YULID
+func (g *Generator) generate(prefix string) (YULID, error) {
+	var yulid YULID
+
+	// write prefix
+	copy(yulid[:prefixLen], prefix)
+
+	// write separator
+	yulid[prefixLen] = g.opts.separator
+
+	// write random part in place, then its check character if any
+	start := prefixLen + separatorLen
+	random := yulid[start : start+g.opts.randomLen()]
+	if g.opts.strategy != nil {
+		if err := g.strategySuffix(prefix, random); err != nil {
+			return YULID{}, err
+		}
+	} else if err := g.opts.random(random, g.opts.alphabet); err != nil {
+		return YULID{}, err
+	} else if g.opts.firstSet != "" {
+		if err := g.opts.random(random[:1], g.opts.firstSet); err != nil {
+			return YULID{}, err
+		}
+	}
+	g.opts.writeSuffix(&yulid, random)
+
+	return yulid, nil
+}
+
+// strategySuffix fills dst from the configured SuffixStrategy. The strategy
+// writes into a scratch buffer of its own so that handing a slice through the
+// interface does not move the caller's YULID to the heap on the default path.
+func (g *Generator) strategySuffix(prefix string, dst []byte) error {
+	var buf [maxSuffixLen]byte
+	scratch := buf[:len(dst)]
+	if err := g.opts.strategy.NextSuffix(prefix, g.opts.alphabet, scratch); err != nil {
+		return err
+	}
+	copy(dst, scratch)
+	return nil
+}
+
+// Parse converts the string form of a YULID in the Generator's format back
+// into a YULID; see the package-level Parse.
+func (g *Generator) Parse(s string) (YULID, error) {
+	return parse(s, g.opts)
+}
+
+// ParseBytes is like Parse but takes a byte slice
+func (g *Generator) ParseBytes(b []byte) (YULID, error) {
+	return parse(b, g.opts)
+}
+
+// ParseLenient is like Parse but strips decorations and uppercases the input;
+// see the package-level ParseLenient.
+func (g *Generator) ParseLenient(s string) (YULID, error) {
+	return parse(strings.ToUpper(undecorate(s)), g.opts)
+}
+
+// Validate checks that id is correctly formatted for the Generator's format
+func (g *Generator) Validate(id YULID) error {
+	return validate(id, g.opts)
+}
+
+// NewBatch generates n distinct YULIDs for prefix. Entropy for the whole batch
+// is drawn in one pass, and any suffix that repeats within the batch, or that
+// the SuffixFilter or UniquenessChecker rejects, is regenerated individually within
+// the retry limit. It returns ErrExhausted if n exceeds the suffix keyspace or
+// the limit is reached.
+func (g *Generator) NewBatch(prefix string, n int) ([]YULID, error) {
+	if g.opts.observer == nil {
+		return g.newBatch(prefix, n)
+	}
+	start := time.Now()
+	batch, err := g.newBatch(prefix, n)
+	g.observe(prefix, start, len(batch), err)
+	return batch, err
+}
+
+func (g *Generator) newBatch(prefix string, n int) ([]YULID, error) {
+	if err := g.checkPrefix(prefix); err != nil {
+		return nil, err
+	}
+	if n < 0 {
+		return nil, ErrNegativeBatchSize
+	}
+
+	randomLen := g.opts.randomLen()
+	if float64(n) > g.opts.keyspace() {
+		return nil, ErrExhausted
+	}
+
+	// draw the whole batch at once unless a SuffixStrategy supplies suffixes
+	var random []byte
+	var err error
+	if g.opts.strategy == nil {
+		random = make([]byte, n*randomLen)
+		if err = g.opts.random(random, g.opts.alphabet); err != nil {
+			return nil, err
+		}
+		if g.opts.firstSet != "" {
+			first := make([]byte, n)
+			if err = g.opts.random(first, g.opts.firstSet); err != nil {
+				return nil, err
+			}
+			for i, c := range first {
+				random[i*randomLen] = c
+			}
+		}
+	}
+
+	var base YULID
+	copy(base[:prefixLen], prefix)
+	base[prefixLen] = g.opts.separator
+
+	batch := make([]YULID, 0, n)
+	seen := make(Set, n)
+	retries := 0
+	for i := 0; len(batch) < n; i++ {
+		id := base
+		switch {
+		case i < n && random != nil:
+			g.opts.writeSuffix(&id, random[i*randomLen:(i+1)*randomLen])
+		case i < n:
+			if id, err = g.generate(prefix); err != nil {
+				return nil, err
+			}
+		default:
+			// the bulk draw produced duplicates or taken IDs; top up one at a time
+			if retries++; retries > g.opts.maxRetries() {
+				return nil, ErrExhausted
+			}
+			if id, err = g.generate(prefix); err != nil {
+				return nil, err
+			}
+		}
+
+		if seen.Contains(id) {
+			g.retried(prefix, RetryDuplicate)
+			continue
+		}
+		ok, err := g.accept(context.Background(), id)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		seen.Add(id)
+		batch = append(batch, id)
+		g.generated.Add(1)
+	}
+
+	return batch, nil
+}
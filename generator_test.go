@@ -0,0 +1,404 @@
+package yulid
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewDoesNotAllocate(t *testing.T) {
+	if _, err := New("JNDE"); err != nil {
+		t.Fatal(err)
+	}
+	allocs := testing.AllocsPerRun(1000, func() {
+		if _, err := New("JNDE"); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs != 0 {
+		t.Fatalf("New allocates %v times per call, want 0", allocs)
+	}
+}
+
+func BenchmarkNew(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := New("JNDE"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// oldSuffix returns a fresh suffix slice, as generateSuffix did before New
+// wrote into the array
+//
+//go:noinline
+func oldSuffix(entropy io.Reader) ([]byte, error) {
+	suffix := make([]byte, maxSuffixLen)
+	if err := generateRandom(suffix, entropy, alphanumeric); err != nil {
+		return nil, err
+	}
+	return suffix, nil
+}
+
+// newViaBuffer builds an ID the way New did before writing into the array,
+// assembling it from a returned suffix slice in an intermediate buffer
+func newViaBuffer(prefix string, entropy io.Reader) (YULID, error) {
+	suffix, err := oldSuffix(entropy)
+	if err != nil {
+		return YULID{}, err
+	}
+	final := make([]byte, prefixLen+separatorLen+maxSuffixLen)
+	copy(final, prefix)
+	final[prefixLen] = '-'
+	copy(final[prefixLen+separatorLen:], suffix)
+
+	var yd YULID
+	copy(yd[:], final)
+	return yd, nil
+}
+
+func TestNewMatchesBufferedConstruction(t *testing.T) {
+	seed := make([]byte, 4096)
+	for i := range seed {
+		seed[i] = byte(i * 7)
+	}
+	g, err := NewGenerator(WithEntropy(bytes.NewReader(seed)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref := bytes.NewReader(seed)
+
+	for i := 0; i < 100; i++ {
+		got, err := g.New("JNDE")
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := newViaBuffer("JNDE", ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Fatalf("New = %q, want %q", got, want)
+		}
+	}
+}
+
+func BenchmarkNewViaBuffer(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := newViaBuffer("JNDE", nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNewParallel(b *testing.B) {
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := New("JNDE"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// TestNewParallelDoesNotAllocate checks that New stays allocation-free while
+// many goroutines share the default Generator and its entropy pool
+func TestNewParallelDoesNotAllocate(t *testing.T) {
+	const goroutines, calls = 8, 2000
+
+	run := func() {
+		var wg sync.WaitGroup
+		for range goroutines {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range calls {
+					if _, err := New("JNDE"); err != nil {
+						t.Error(err)
+						return
+					}
+				}
+			}()
+		}
+		wg.Wait()
+	}
+	run() // warm the pool
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	run()
+	runtime.ReadMemStats(&after)
+
+	// average per call, truncated as testing.AllocsPerRun does, so the
+	// goroutines themselves and runtime background work don't count
+	if allocs := (after.Mallocs - before.Mallocs) / (goroutines * calls); allocs != 0 {
+		t.Fatalf("parallel New allocates %v times per call, want 0", allocs)
+	}
+}
+
+func BenchmarkGeneratorNewParallel(b *testing.B) {
+	g, err := NewGenerator(WithSuffixLength(5), WithChecksum())
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := g.New("JNDE"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestConfigure(t *testing.T) {
+	defer Configure()
+
+	if err := Configure(WithSuffixLength(4), WithSeparator('.')); err != nil {
+		t.Fatal(err)
+	}
+	id, err := New("JNDE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.Len() != 9 || id[prefixLen] != '.' {
+		t.Fatalf("New = %q, want the configured format", id)
+	}
+	if err := Validate(id); err != nil {
+		t.Fatalf("Validate(%q) under the configured format: %v", id, err)
+	}
+	if _, err := Parse("JNDE-AB12CD"); err == nil {
+		t.Fatal("Parse accepted the standard format after Configure")
+	}
+
+	if err := Configure(WithSuffixLength(9)); !errors.Is(err, ErrInvalidOption) {
+		t.Fatalf("Configure with a bad option: err = %v, want ErrInvalidOption", err)
+	}
+	if id, _ := New("JNDE"); id.Len() != 9 {
+		t.Fatalf("a failed Configure changed the default: New = %q", id)
+	}
+
+	if err := Configure(); err != nil {
+		t.Fatal(err)
+	}
+	if id := MustNew("JNDE"); id.Len() != maxLen || id[prefixLen] != '-' {
+		t.Fatalf("Configure() did not restore the standard format: New = %q", id)
+	}
+	if _, _, err := ParseVersioned("JNDE-AB12CD"); err != nil {
+		t.Fatalf("ParseVersioned: %v", err)
+	}
+}
+
+func TestNewBatchDistinct(t *testing.T) {
+	g, err := NewGenerator(WithSuffixLength(4), WithAlphabet("ABCD"), WithMaxRetries(100000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the whole 4^4 keyspace, so the bulk draw is certain to repeat
+	batch, err := g.NewBatch("JNDE", 256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := make(Set, len(batch))
+	for _, id := range batch {
+		if err := g.Validate(id); err != nil {
+			t.Fatalf("Validate(%q): %v", id, err)
+		}
+		if seen.Contains(id) {
+			t.Fatalf("NewBatch returned %q twice", id)
+		}
+		seen.Add(id)
+	}
+
+	if _, err := g.NewBatch("JNDE", 257); !errors.Is(err, ErrExhausted) {
+		t.Fatalf("NewBatch beyond the keyspace: err = %v, want ErrExhausted", err)
+	}
+}
+
+func BenchmarkNewBatch(b *testing.B) {
+	const n = 1000
+	g := defaultGenerator()
+
+	b.Run("NewBatch", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := g.NewBatch("JNDE", n); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("NewLoop", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			batch := make([]YULID, 0, n)
+			for len(batch) < n {
+				id, err := g.New("JNDE")
+				if err != nil {
+					b.Fatal(err)
+				}
+				batch = append(batch, id)
+			}
+		}
+	})
+}
+
+func TestMaxRetriesExhausts(t *testing.T) {
+	// every ID over a two-letter alphabet, so the checker rejects them all
+	full := NewMemoryStore()
+	for n := 0; n < 16; n++ {
+		id := YULID{'J', 'N', 'D', 'E', '-'}
+		for i := range 4 {
+			id[prefixLen+separatorLen+i] = "AB"[n>>i&1]
+		}
+		full.Add(id)
+	}
+	rejectAll := SuffixFilterFunc(func(prefix, suffix string) bool { return true })
+	invalidAll := func(YULID) error { return errors.New("rejected") }
+
+	tests := []struct {
+		name  string
+		opt   Option
+		count func(Stats) uint64
+	}{
+		{"checker", WithUniquenessChecker(full), func(s Stats) uint64 { return s.Collisions }},
+		{"filter", WithSuffixFilter(rejectAll), func(s Stats) uint64 { return s.FilterRejections }},
+		{"validator", WithValidator(invalidAll), func(s Stats) uint64 { return s.ValidatorRejections }},
+	}
+	for _, tt := range tests {
+		for _, retries := range []int{0, 3} {
+			opts := []Option{WithAlphabet("AB"), WithSuffixLength(4), tt.opt}
+			want := uint64(defaultMaxRetries + 1)
+			if retries > 0 {
+				opts = append(opts, WithMaxRetries(retries))
+				want = uint64(retries + 1)
+			}
+			g, err := NewGenerator(opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := g.New("JNDE"); !errors.Is(err, ErrKeyspaceExhausted) {
+				t.Fatalf("%s, %d retries: err = %v, want ErrKeyspaceExhausted", tt.name, retries, err)
+			}
+			if got := tt.count(g.Stats()); got != want {
+				t.Errorf("%s, %d retries: %d candidates tried, want %d", tt.name, retries, got, want)
+			}
+		}
+	}
+}
+
+// zeroReader is an entropy source that always draws the first alphabet
+// character, so every generated ID is the same
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+// drawCounter counts the candidates discarded by a Generator in drawn
+type drawCounter struct{ drawn *int }
+
+func (drawCounter) OnGenerate(string, int, time.Duration) {}
+func (c drawCounter) OnRetry(string, RetryReason)         { *c.drawn++ }
+func (drawCounter) OnError(string, error)                 {}
+
+// TestMaxRetriesBoundsHelpers checks that the package-level helpers that
+// regenerate IDs honor the retry limit of the configured Generator.
+func TestMaxRetriesBoundsHelpers(t *testing.T) {
+	defer Configure()
+
+	// candidates are counted as the validator accepts them or the Generator
+	// discards them as duplicates
+	var drawn int
+	count := []Option{WithValidator(func(YULID) error { drawn++; return nil }), WithObserver(drawCounter{&drawn})}
+	tests := []struct {
+		name string
+		call func() error
+		want func(limit int) int // IDs drawn under a retry limit
+	}{
+		{"NewExcluding", func() error {
+			_, err := NewExcluding("JNDE", NewSet(MustParse("JNDE-AAAA", WithAlphabet("AB"))))
+			return err
+		}, func(limit int) int { return limit + 1 }},
+		{"NewBatchMinDistance", func() error {
+			// a 4-character suffix can never differ from another in 5 positions
+			_, err := NewBatchMinDistance("JNDE", 2, 5)
+			return err
+		}, func(limit int) int { return 1 + limit + 1 }},
+		{"WriteBatch", func() error {
+			_, err := WriteBatch(io.Discard, "JNDE", 2, '\n')
+			return err
+		}, func(limit int) int { return 1 + limit + 1 }},
+		{"NewRandomPrefix", func() error {
+			// every prefix spells the reserved AAAA, so no ID is drawn
+			_, err := NewRandomPrefix()
+			return err
+		}, func(int) int { return 0 }},
+	}
+	for _, tt := range tests {
+		for _, retries := range []int{0, 3} {
+			opts := append([]Option{WithAlphabet("AB"), WithSuffixLength(4), WithEntropy(zeroReader{}), WithMaxRetries(retries)}, count...)
+			if err := Configure(opts...); err != nil {
+				t.Fatal(err)
+			}
+			drawn = 0
+			if err := tt.call(); !errors.Is(err, ErrKeyspaceExhausted) {
+				t.Fatalf("%s, %d retries: err = %v, want ErrKeyspaceExhausted", tt.name, retries, err)
+			}
+			limit := retries
+			if limit == 0 {
+				limit = defaultMaxRetries
+			}
+			if want := tt.want(limit); drawn != want {
+				t.Errorf("%s, %d retries: %d candidates drawn, want %d", tt.name, retries, drawn, want)
+			}
+		}
+	}
+}
+
+// TestValidateByProfile checks each format's IDs against Generators for the
+// other formats: a Generator is the profile an ID is expected to match.
+func TestValidateByProfile(t *testing.T) {
+	profiles := map[string][]Option{
+		"plain":       nil,
+		"short":       {WithSuffixLength(4), WithSeparator('.')},
+		"checksummed": {WithChecksum(), WithSeparator('_')},
+		"lowercase":   {WithAlphabet("abcdefghijklmnopqrstuvwxyz")},
+	}
+	gens := make(map[string]*Generator, len(profiles))
+	ids := make(map[string]YULID, len(profiles))
+	for name, opts := range profiles {
+		g, err := NewGenerator(opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gens[name], ids[name] = g, mustNew(t, g, "JNDE")
+	}
+
+	for name, g := range gens {
+		for idName, id := range ids {
+			err := g.Validate(id)
+			if match := name == idName; match != (err == nil) {
+				t.Errorf("%s profile validating a %s ID %q: err = %v", name, idName, id, err)
+			}
+		}
+	}
+
+	// a single substituted character breaks the check character
+	id := ids["checksummed"]
+	if pos := prefixLen + separatorLen; id[pos] == 'A' {
+		id[pos] = 'B'
+	} else {
+		id[pos] = 'A'
+	}
+	if err := gens["checksummed"].Validate(id); err == nil {
+		t.Errorf("checksummed profile accepted %q", id)
+	}
+}
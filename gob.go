@@ -0,0 +1,31 @@
+package yulid
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+)
+
+var (
+	_ gob.GobEncoder = YULID{}
+	_ gob.GobDecoder = (*YULID)(nil)
+)
+
+// GobEncode implements gob.GobEncoder. gob prefers it over MarshalBinary,
+// whose packed form only holds YULIDs in the default format, so IDs in any
+// format survive a gob round trip. The encoding is the raw characters of yd
+// without its trailing zero padding and is not validated, so even a malformed
+// YULID comes back exactly as it was sent.
+func (yd YULID) GobEncode() ([]byte, error) {
+	return bytes.TrimRight(yd[:], "\x00"), nil
+}
+
+// GobDecode implements gob.GobDecoder, reversing GobEncode
+func (yd *YULID) GobDecode(data []byte) error {
+	if len(data) > len(yd) {
+		return errors.New("gob-encoded YULID is too long")
+	}
+	*yd = YULID{}
+	copy(yd[:], data)
+	return nil
+}
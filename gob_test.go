@@ -0,0 +1,73 @@
+package yulid
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+)
+
+func TestGobRoundTrip(t *testing.T) {
+	dotted, err := NewGenerator(WithSeparator('.'))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]YULID{
+		"zero":      {},
+		"default":   MustParse("JNDE-AB12CD"),
+		"short":     MustParse("JNDE-AB12"),
+		"separator": mustNew(t, dotted, "JNDE"),
+		"malformed": {'j', 0, '-', 0xff, 'x'},
+	}
+	for name, id := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := gob.NewEncoder(&buf).Encode(id); err != nil {
+				t.Fatalf("Encode(%q): %v", id, err)
+			}
+			var got YULID
+			if err := gob.NewDecoder(&buf).Decode(&got); err != nil {
+				t.Fatalf("Decode(%q): %v", id, err)
+			}
+			if got != id {
+				t.Fatalf("round trip of %q gave %q", id, got)
+			}
+		})
+	}
+}
+
+func TestGobInStruct(t *testing.T) {
+	type job struct {
+		Customer YULID
+		Parent   *YULID
+	}
+	parent := MustParse("JNDE-PARENT")
+	in := job{Customer: MustParse("JNDE-AB12CD"), Parent: &parent}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatal(err)
+	}
+	var out job
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Customer != in.Customer || out.Parent == nil || *out.Parent != parent {
+		t.Fatalf("decoded %+v, want %+v", out, in)
+	}
+}
+
+func TestGobDecodeTooLong(t *testing.T) {
+	var id YULID
+	if err := id.GobDecode([]byte("JNDE-AB12CDEF")); err == nil {
+		t.Fatal("GobDecode accepted 13 bytes")
+	}
+}
+
+func mustNew(t *testing.T, g *Generator, prefix string) YULID {
+	t.Helper()
+	id, err := g.New(prefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
@@ -0,0 +1,23 @@
+package yulid
+
+import "fmt"
+
+// Identifier is the common contract for human-readable ID types, letting
+// consumers write code that is generic over ID implementations.
+type Identifier interface {
+	fmt.Stringer
+	Validate() error
+	Bytes() []byte
+}
+
+var _ Identifier = YULID{}
+
+// Validate checks that yd is correctly formatted; see the package-level Validate
+func (yd YULID) Validate() error {
+	return Validate(yd)
+}
+
+// Bytes returns the canonical form of yd as a byte slice, without padding
+func (yd YULID) Bytes() []byte {
+	return []byte(yd.String())
+}
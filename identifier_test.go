@@ -0,0 +1,21 @@
+package yulid
+
+import "testing"
+
+func TestYULIDAsIdentifier(t *testing.T) {
+	var id Identifier = MustParse("JNDE-AB12")
+	if id.String() != "JNDE-AB12" {
+		t.Errorf("String = %q", id.String())
+	}
+	if b := id.Bytes(); string(b) != "JNDE-AB12" {
+		t.Errorf("Bytes = %q, want no padding", b)
+	}
+	if err := id.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+
+	var bad Identifier = YULID{'J', 'N', 'D', 'E', '_', 'A', 'B', '1', '2'}
+	if err := bad.Validate(); err == nil {
+		t.Error("Validate accepted a bad separator through the interface")
+	}
+}
@@ -0,0 +1,45 @@
+package yulid
+
+import "sync"
+
+// maxInterned bounds the intern cache; once full, Intern stops caching new values
+const maxInterned = 1 << 16
+
+var (
+	internMu sync.RWMutex
+	interned = make(map[YULID]string)
+)
+
+// Intern returns a shared string for yd, so repeated values held as strings
+// don't each carry their own copy.
+//
+// Interning only pays off for long-lived values that recur often. The cache is
+// bounded: once it holds maxInterned entries, new values are returned uncached
+// until ClearInterned is called.
+func Intern(yd YULID) string {
+	internMu.RLock()
+	s, ok := interned[yd]
+	internMu.RUnlock()
+	if ok {
+		return s
+	}
+
+	internMu.Lock()
+	defer internMu.Unlock()
+
+	if s, ok := interned[yd]; ok {
+		return s
+	}
+	s = yd.String()
+	if len(interned) < maxInterned {
+		interned[yd] = s
+	}
+	return s
+}
+
+// ClearInterned empties the intern cache
+func ClearInterned() {
+	internMu.Lock()
+	defer internMu.Unlock()
+	clear(interned)
+}
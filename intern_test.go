@@ -0,0 +1,72 @@
+package yulid
+
+import (
+	"testing"
+	"unsafe"
+)
+
+func TestInternSharesString(t *testing.T) {
+	defer ClearInterned()
+
+	id := MustParse("JNDE-ED24HS")
+	a, b := Intern(id), Intern(MustParse("JNDE-ED24HS"))
+	if a != id.String() {
+		t.Fatalf("Intern = %q, want %q", a, id)
+	}
+	if unsafe.StringData(a) != unsafe.StringData(b) {
+		t.Fatal("Intern returned separate copies for equal IDs")
+	}
+
+	ClearInterned()
+	if c := Intern(id); unsafe.StringData(c) == unsafe.StringData(a) {
+		t.Fatal("Intern returned a cached string after ClearInterned")
+	}
+}
+
+func TestInternBounded(t *testing.T) {
+	defer ClearInterned()
+	ClearInterned()
+
+	ids, err := standardGenerator.NewBatch("JNDE", maxInterned+10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range ids {
+		Intern(id)
+	}
+
+	internMu.RLock()
+	n := len(interned)
+	internMu.RUnlock()
+	if n != maxInterned {
+		t.Fatalf("cache holds %d entries, want the bound %d", n, maxInterned)
+	}
+	if s := Intern(ids[len(ids)-1]); s != ids[len(ids)-1].String() {
+		t.Fatalf("uncached Intern = %q, want %q", s, ids[len(ids)-1])
+	}
+}
+
+// BenchmarkIntern converts a small set of recurring IDs to strings, comparing
+// Intern with String
+func BenchmarkIntern(b *testing.B) {
+	ids, err := standardGenerator.NewBatch("JNDE", 64)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(ClearInterned)
+
+	b.Run("String", func(b *testing.B) {
+		b.ReportAllocs()
+		var s []string
+		for i := 0; i < b.N; i++ {
+			s = append(s[:0], ids[i%len(ids)].String())
+		}
+	})
+	b.Run("Intern", func(b *testing.B) {
+		b.ReportAllocs()
+		var s []string
+		for i := 0; i < b.N; i++ {
+			s = append(s[:0], Intern(ids[i%len(ids)]))
+		}
+	})
+}
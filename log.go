@@ -0,0 +1,12 @@
+package yulid
+
+import "log/slog"
+
+var _ slog.LogValuer = YULID{}
+
+// LogValue implements slog.LogValuer, so YULIDs passed to structured logging
+// are recorded masked as by Masked. Log yd.String() explicitly where the full
+// ID is required.
+func (yd YULID) LogValue() slog.Value {
+	return slog.StringValue(yd.Masked())
+}
@@ -0,0 +1,37 @@
+package yulid
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLogValueMasked(t *testing.T) {
+	id := MustParse("JNDE-ED24HS")
+
+	var text, js bytes.Buffer
+	for _, h := range []slog.Handler{slog.NewTextHandler(&text, nil), slog.NewJSONHandler(&js, nil)} {
+		logger := slog.New(h)
+		logger.Info("minted", "id", id)
+		logger.Info("minted", slog.Any("ptr", &id))
+		logger.With("owner", id).Info("minted")
+		logger.Info("minted", slog.Group("order", "customer", id))
+	}
+
+	for name, out := range map[string]string{"text": text.String(), "JSON": js.String()} {
+		if strings.Contains(out, id.String()) || strings.Contains(out, "ED24") {
+			t.Errorf("%s log contains the full ID:\n%s", name, out)
+		}
+		if got := strings.Count(out, "JNDE-****HS"); got != 4 {
+			t.Errorf("%s log has %d masked IDs, want 4:\n%s", name, got, out)
+		}
+	}
+
+	if got := id.LogValue(); got.Kind() != slog.KindString || got.String() != "JNDE-****HS" {
+		t.Errorf("LogValue = %v, want the masked string", got)
+	}
+	if got := (YULID{}).LogValue().String(); got != "" {
+		t.Errorf("LogValue of the zero YULID = %q", got)
+	}
+}
@@ -0,0 +1,78 @@
+package yulid
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// MonotonicTimedGenerator generates YULIDs in the NewSortable layout whose
+// last two characters count the IDs issued under each prefix within the hour,
+// instead of being random, in the style of monotonic ULIDs. IDs from one
+// MonotonicTimedGenerator never collide and sort in issue order, and up to
+// 1296 can be issued per prefix per hour; beyond that New returns
+// ErrKeyspaceExhausted until the hour turns. The counter makes consecutive IDs
+// easy to guess, so they should not be used where that matters. Like
+// NewSortable, it always uses the standard format regardless of Configure.
+//
+// The zero value is ready to use and reads the system clock. It is safe for
+// concurrent use.
+type MonotonicTimedGenerator struct {
+	// Clock returns the current time; nil means time.Now. If it moves back
+	// within or past an hour already seen, IDs continue in the later hour so
+	// they stay monotonic.
+	Clock func() time.Time
+
+	mu       sync.Mutex
+	tick     int64          // hours since the epoch of the current counters
+	counters map[string]int // next counter value per prefix in tick
+}
+
+// New generates the next YULID for prefix
+func (m *MonotonicTimedGenerator) New(prefix string) (YULID, error) {
+	if err := standardGenerator.checkPrefix(prefix); err != nil {
+		return YULID{}, err
+	}
+	now := timeNow
+	if m.Clock != nil {
+		now = m.Clock
+	}
+	t := now()
+	if _, err := encodeTime(t); err != nil {
+		return YULID{}, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if tick := int64(t.Sub(sortableEpoch) / sortableUnit); tick > m.tick || m.counters == nil {
+		m.tick, m.counters = max(tick, m.tick), make(map[string]int)
+	}
+	stamp, err := encodeTime(sortableEpoch.Add(time.Duration(m.tick) * sortableUnit))
+	if err != nil {
+		return YULID{}, err
+	}
+	n := m.counters[prefix]
+	if n >= timedTails {
+		return YULID{}, ErrKeyspaceExhausted
+	}
+	m.counters[prefix] = n + 1
+
+	var yd YULID
+	copy(yd[:], prefix)
+	yd[prefixLen] = '-'
+	copy(yd[prefixLen+separatorLen:], stamp)
+	yd[maxLen-2], yd[maxLen-1] = timeDigits[n/len(timeDigits)], timeDigits[n%len(timeDigits)]
+	return yd, nil
+}
+
+// MonotonicCounter returns the counter of a YULID from a
+// MonotonicTimedGenerator, its position among the IDs issued under its prefix
+// in the hour given by Timestamp. It returns false if yd is not a valid YULID
+// with a 6-character suffix.
+func MonotonicCounter(yd YULID) (int, bool) {
+	if sortableGenerator.Validate(yd) != nil {
+		return 0, false
+	}
+	return strings.IndexByte(timeDigits, yd[maxLen-2])*len(timeDigits) + strings.IndexByte(timeDigits, yd[maxLen-1]), true
+}
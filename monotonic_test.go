@@ -0,0 +1,101 @@
+package yulid
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMonotonicTimedGenerator(t *testing.T) {
+	at := time.Date(2026, time.January, 2, 3, 4, 5, 0, time.UTC)
+	m := &MonotonicTimedGenerator{Clock: func() time.Time { return at }}
+
+	var prev YULID
+	for i := 0; i < timedTails; i++ {
+		id, err := m.New("JNDE")
+		if err != nil {
+			t.Fatalf("New #%d: %v", i, err)
+		}
+		if i > 0 && id.String() <= prev.String() {
+			t.Fatalf("%q does not sort after %q", id, prev)
+		}
+		if got, ok := Timestamp(id); !ok || !got.Equal(at.Truncate(time.Hour)) {
+			t.Fatalf("Timestamp(%q) = %v, want %v", id, got, at.Truncate(time.Hour))
+		}
+		if n, ok := MonotonicCounter(id); !ok || n != i {
+			t.Fatalf("MonotonicCounter(%q) = %d, %v; want %d", id, n, ok, i)
+		}
+		prev = id
+	}
+	if _, err := m.New("JNDE"); !errors.Is(err, ErrKeyspaceExhausted) {
+		t.Fatalf("New past the tick's keyspace: err = %v, want ErrKeyspaceExhausted", err)
+	}
+
+	// other prefixes have their own counters
+	if id, err := m.New("MSMT"); err != nil {
+		t.Fatal(err)
+	} else if n, _ := MonotonicCounter(id); n != 0 {
+		t.Fatalf("first MSMT ID has counter %d", n)
+	}
+
+	// the counter resets when the tick advances, and a clock moving back stays in the later tick
+	at = at.Add(time.Hour)
+	id, err := m.New("JNDE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := MonotonicCounter(id); n != 0 {
+		t.Fatalf("counter after the tick advanced = %d, want 0", n)
+	}
+	at = at.Add(-2 * time.Hour)
+	back, err := m.New("JNDE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back.String() <= id.String() {
+		t.Fatalf("%q, issued after the clock moved back, sorts before %q", back, id)
+	}
+}
+
+func TestMonotonicTimedGeneratorConcurrent(t *testing.T) {
+	at := time.Date(2026, time.January, 2, 3, 4, 5, 0, time.UTC)
+	var m MonotonicTimedGenerator
+	m.Clock = func() time.Time { return at }
+
+	var (
+		mu   sync.Mutex
+		seen = make(Set)
+		wg   sync.WaitGroup
+	)
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				id, err := m.New("JNDE")
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				mu.Lock()
+				if seen.Contains(id) {
+					t.Errorf("%q issued twice", id)
+				}
+				seen.Add(id)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if len(seen) != 800 {
+		t.Fatalf("issued %d distinct IDs, want 800", len(seen))
+	}
+}
+
+func TestMonotonicTimedGeneratorBeforeEpoch(t *testing.T) {
+	m := &MonotonicTimedGenerator{Clock: func() time.Time { return sortableEpoch.Add(-time.Hour) }}
+	if id, err := m.New("JNDE"); err == nil {
+		t.Fatalf("New before the epoch = %q", id)
+	}
+}
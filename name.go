@@ -0,0 +1,105 @@
+package yulid
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrInvalidName = errors.New("name contains no letters or digits to derive a prefix from")
+)
+
+// NewFromName generates a YULID whose prefix is derived from fullName by
+// PrefixFromName, using opts as for New. WithTransliterator changes how
+// non-ASCII names are folded.
+func NewFromName(fullName string, opts ...Option) (YULID, error) {
+	g, err := generatorFor(opts)
+	if err != nil {
+		return YULID{}, err
+	}
+	return g.NewFromName(fullName)
+}
+
+// NewFromName generates a YULID whose prefix is derived from fullName; see the
+// package-level NewFromName.
+func (g *Generator) NewFromName(fullName string) (YULID, error) {
+	prefix, err := g.PrefixFromName(fullName)
+	if err != nil {
+		return YULID{}, err
+	}
+	return g.New(prefix)
+}
+
+// PrefixFromName derives a prefix from fullName with the default
+// transliterator; see Generator.PrefixFromName.
+func PrefixFromName(fullName string) (string, error) {
+	return defaultGenerator().PrefixFromName(fullName)
+}
+
+// PrefixFromName deterministically derives a 4-character prefix from a full
+// name. The name is first passed through the Generator's Transliterator, which
+// by default folds accented Latin and Cyrillic letters to ASCII ("Łukasz Żółty"
+// becomes "LUKASZ ZOLTY"), and then only ASCII letters and digits are
+// considered, case-insensitively.
+//
+//   - With two or more words, the prefix is the first and last character of the
+//     first and of the last word: "John Doe" gives "JNDE".
+//   - With one word, it is the first character followed by the word's
+//     consonants, then its remaining characters: "Madonna" gives "MDNN".
+//   - Names too short to fill four characters are padded with characters
+//     derived from a hash of the whole name, so short names don't all share a
+//     padded prefix like "ALXX": "Al" always pads the same way, but differently
+//     from "Bo".
+//   - Names with letters but nothing that transliterates, such as "山田太郎" under
+//     the default Transliterator, get a prefix derived entirely from a hash of
+//     the name. Such prefixes are stable but carry no meaning.
+func (g *Generator) PrefixFromName(fullName string) (string, error) {
+	words := strings.FieldsFunc(strings.ToUpper(g.opts.transliterator.Transliterate(fullName)), func(r rune) bool {
+		return !isAlphanumeric(r)
+	})
+	if len(words) == 0 {
+		if !strings.ContainsFunc(fullName, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsNumber(r) }) {
+			return "", ErrInvalidName
+		}
+		words = []string{strings.Join(strings.Fields(fullName), " ")}
+		return hashPad(nil, words), nil
+	}
+
+	var prefix []byte
+	if len(words) >= 2 {
+		first, last := words[0], words[len(words)-1]
+		prefix = append(prefix, first[0], first[len(first)-1], last[0], last[len(last)-1])
+	} else {
+		word := words[0]
+		prefix = append(prefix, word[0])
+		for i := 1; i < len(word) && len(prefix) < prefixLen; i++ {
+			if isConsonant(word[i]) {
+				prefix = append(prefix, word[i])
+			}
+		}
+		for i := 1; i < len(word) && len(prefix) < prefixLen; i++ {
+			if !isConsonant(word[i]) {
+				prefix = append(prefix, word[i])
+			}
+		}
+	}
+
+	return hashPad(prefix, words), nil
+}
+
+// hashPad fills prefix up to prefixLen with characters derived from a hash of
+// words rather than a fixed filler
+func hashPad(prefix []byte, words []string) string {
+	h := hash64([]byte(strings.Join(words, " ")))
+	for len(prefix) < prefixLen {
+		prefix = append(prefix, alphanumeric[h%uint64(len(alphanumeric))])
+		h /= uint64(len(alphanumeric))
+	}
+	return string(prefix)
+}
+
+// isConsonant reports whether b is an uppercase ASCII consonant
+func isConsonant(b byte) bool {
+	return b >= 'A' && b <= 'Z' && !strings.ContainsRune("AEIOU", rune(b))
+}
@@ -0,0 +1,150 @@
+package yulid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPrefixFromName(t *testing.T) {
+	tests := []struct {
+		name, want string
+	}{
+		{"John Doe", "JNDE"},
+		{"john doe", "JNDE"},
+		{"  John   Q.  Doe  ", "JNDE"}, // middle names are skipped
+		{"Mary-Jane O'Neil", "MYNL"},   // punctuation splits words
+		{"Madonna", "MDNN"},            // first letter, then consonants
+		{"Aeiou", "AEIO"},              // then the remaining characters
+		{"Bob", "BBO"},                 // and hash padding when short
+		{"Łukasz Żółty", "LZZY"},       // folded to LUKASZ ZOLTY
+		{"R2 D2", "R2D2"},
+	}
+	for _, tt := range tests {
+		got, err := PrefixFromName(tt.name)
+		if err != nil {
+			t.Errorf("PrefixFromName(%q): %v", tt.name, err)
+			continue
+		}
+		if !strings.HasPrefix(got, tt.want) || len(got) != prefixLen {
+			t.Errorf("PrefixFromName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+		if err := validatePrefix(got); err != nil {
+			t.Errorf("PrefixFromName(%q) = %q, not a valid prefix: %v", tt.name, got, err)
+		}
+	}
+}
+
+func TestPrefixFromNameDeterministic(t *testing.T) {
+	names := []string{"John Doe", "Al", "Bo", "X", "Bob", "山田太郎", "鈴木一郎"}
+	prefixes := make(map[string]string, len(names))
+	for _, name := range names {
+		first, err := PrefixFromName(name)
+		if err != nil {
+			t.Fatalf("PrefixFromName(%q): %v", name, err)
+		}
+		for i := 0; i < 10; i++ {
+			if again, _ := PrefixFromName(name); again != first {
+				t.Fatalf("PrefixFromName(%q) gave %q then %q", name, first, again)
+			}
+		}
+		prefixes[name] = first
+	}
+
+	// short and untransliterable names are padded from a hash of the name
+	// rather than a shared filler
+	if !strings.HasPrefix(prefixes["Al"], "AL") || !strings.HasPrefix(prefixes["Bo"], "BO") {
+		t.Errorf("short names lost their letters: %q, %q", prefixes["Al"], prefixes["Bo"])
+	}
+	if prefixes["Al"][2:] == prefixes["Bo"][2:] {
+		t.Errorf("Al and Bo share the padding %q", prefixes["Al"][2:])
+	}
+	if prefixes["山田太郎"] == prefixes["鈴木一郎"] {
+		t.Errorf("untransliterable names share the prefix %q", prefixes["山田太郎"])
+	}
+}
+
+func TestPrefixFromNameRejectsEmpty(t *testing.T) {
+	for _, name := range []string{"", "   ", "!!! ---", "\t\n"} {
+		if got, err := PrefixFromName(name); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("PrefixFromName(%q) = %q, %v; want ErrInvalidName", name, got, err)
+		}
+		if _, err := NewFromName(name); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("NewFromName(%q): err = %v, want ErrInvalidName", name, err)
+		}
+	}
+}
+
+func TestNewFromName(t *testing.T) {
+	seen := make(Set)
+	for i := 0; i < 50; i++ {
+		id, err := NewFromName("John Doe")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id.Prefix() != "JNDE" {
+			t.Fatalf("NewFromName(John Doe) = %q, want prefix JNDE", id)
+		}
+		seen.Add(id)
+	}
+	if len(seen) < 2 {
+		t.Fatal("NewFromName suffixes are not random")
+	}
+
+	id, err := NewFromName("John Doe", WithSeparator('.'), WithSuffixLength(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.Prefix() != "JNDE" || !strings.HasPrefix(id.String(), "JNDE.") || id.Len() != minLen {
+		t.Fatalf("NewFromName with options = %q", id)
+	}
+}
+
+func TestPrefixFromNameTransliterator(t *testing.T) {
+	g, err := NewGenerator(WithTransliterator(TransliteratorFunc(func(name string) string {
+		return strings.ReplaceAll(name, "ß", "SS")
+	})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := g.PrefixFromName("Strauß"); err != nil || got != "STRS" {
+		t.Errorf("PrefixFromName(Strauß) under a custom transliterator = %q, %v; want STRS", got, err)
+	}
+}
+
+func TestFromNameKeyAndSalt(t *testing.T) {
+	const name = "John Doe"
+	plain, err := FromName(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := FromName(name); again != plain {
+		t.Fatalf("FromName gave %q then %q", plain, again)
+	}
+
+	variants := map[string]YULID{"unkeyed": plain}
+	for label, opts := range map[string][]Option{
+		"key":          {WithDerivationKey([]byte("k1"))},
+		"other key":    {WithDerivationKey([]byte("k2"))},
+		"salt":         {WithSalt([]byte("staging"))},
+		"key and salt": {WithDerivationKey([]byte("k1")), WithSalt([]byte("staging"))},
+	} {
+		id, err := FromName(name, opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id.Prefix() != "JNDE" {
+			t.Errorf("%s: FromName = %q, the prefix must not depend on the key or salt", label, id)
+		}
+		for other, o := range variants {
+			if o == id {
+				t.Errorf("%s and %s both derive %q", label, other, id)
+			}
+		}
+		variants[label] = id
+	}
+
+	if other, _ := FromName("Jane Doe"); other == plain {
+		t.Errorf("John Doe and Jane Doe both derive %q", plain)
+	}
+}
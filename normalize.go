@@ -0,0 +1,74 @@
+package yulid
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// NormalizeLength checks that every ID in ids fits in a suffix of target
+// characters and returns them with consistent zero padding, ready for a
+// fixed-width column. Prefixes and suffix characters are never changed; an ID
+// whose suffix is longer than target is an error.
+func NormalizeLength(ids []YULID, target int) ([]YULID, error) {
+	if target < minSuffixLen || target > maxSuffixLen {
+		return nil, fmt.Errorf("target suffix length %d is out of range", target)
+	}
+
+	out := make([]YULID, len(ids))
+	for i, id := range ids {
+		if err := Validate(id); err != nil {
+			return nil, fmt.Errorf("YULID at index %d: %w", i, err)
+		}
+		s := id.String()
+		if len(s)-prefixLen-separatorLen > target {
+			return nil, fmt.Errorf("YULID %s at index %d has a suffix longer than %d", s, i, target)
+		}
+		copy(out[i][:], s)
+	}
+
+	return out, nil
+}
+
+// Normalize returns the canonical form of yd, replacing trailing space padding,
+// as read from fixed-width CHAR columns, with zero padding. Bytes before the
+// padding must form a valid YULID; a zero or space inside the ID is an error.
+func (yd YULID) Normalize() (YULID, error) {
+	end := len(yd)
+	for end > 0 && (yd[end-1] == 0 || yd[end-1] == ' ') {
+		end--
+	}
+
+	var out YULID
+	copy(out[:], yd[:end])
+	for _, b := range out[:end] {
+		if b == 0 || b == ' ' {
+			return YULID{}, errors.New("YULID contains padding inside the ID")
+		}
+	}
+	if err := Validate(out); err != nil {
+		return YULID{}, err
+	}
+
+	return out, nil
+}
+
+// zeroWidth removes the invisible characters that ride along when IDs are
+// pasted from spreadsheets and web pages: zero-width spaces and joiners, word
+// joiners and the byte order mark
+var zeroWidth = strings.NewReplacer("\u200b", "", "\u200c", "", "\u200d", "", "\u2060", "", "\ufeff", "")
+
+// quotes are the pairs of quotes ParseLenient trims from around an ID
+var quotes = [][2]string{{`"`, `"`}, {"'", "'"}, {"\u201c", "\u201d"}, {"\u2018", "\u2019"}}
+
+// undecorate strips zero-width characters, surrounding whitespace and one
+// pair of surrounding quotes from s
+func undecorate(s string) string {
+	s = strings.TrimSpace(zeroWidth.Replace(s))
+	for _, q := range quotes {
+		if len(s) >= len(q[0])+len(q[1]) && strings.HasPrefix(s, q[0]) && strings.HasSuffix(s, q[1]) {
+			return strings.TrimSpace(s[len(q[0]) : len(s)-len(q[1])])
+		}
+	}
+	return s
+}
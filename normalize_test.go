@@ -0,0 +1,73 @@
+package yulid
+
+import "testing"
+
+func TestNormalizeLength(t *testing.T) {
+	ids := []YULID{MustParse("JNDE-AB12"), MustParse("JNDE-AB12C"), MustParse("ACME-XY9Z")}
+
+	out, err := NormalizeLength(ids, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, id := range out {
+		if id != ids[i] || id.String() != ids[i].String() {
+			t.Errorf("NormalizeLength changed %q to %q", ids[i], id)
+		}
+	}
+	if _, err := NormalizeLength(ids, 4); err == nil {
+		t.Error("NormalizeLength accepted a target shorter than a suffix")
+	}
+	for _, target := range []int{3, 7} {
+		if _, err := NormalizeLength(ids, target); err == nil {
+			t.Errorf("NormalizeLength accepted target %d", target)
+		}
+	}
+	if _, err := NormalizeLength([]YULID{{'J', 'N', 'D', 'E'}}, 6); err == nil {
+		t.Error("NormalizeLength accepted an invalid ID")
+	}
+}
+
+func TestNormalizeLengthZeroesPadding(t *testing.T) {
+	// stray bytes after the zero terminator are dropped
+	id := MustParse("JNDE-AB12")
+	id[maxLen-1] = 'X'
+	out, err := NormalizeLength([]YULID{id}, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out[0] != MustParse("JNDE-AB12") {
+		t.Fatalf("NormalizeLength = %q, want the padding zeroed", out[0][:])
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	want := MustParse("JNDE-AB12")
+	inputs := []YULID{
+		{'J', 'N', 'D', 'E', '-', 'A', 'B', '1', '2'},
+		{'J', 'N', 'D', 'E', '-', 'A', 'B', '1', '2', ' ', ' '},
+		{'J', 'N', 'D', 'E', '-', 'A', 'B', '1', '2', ' ', 0},
+	}
+	for _, in := range inputs {
+		got, err := in.Normalize()
+		if err != nil {
+			t.Fatalf("Normalize(%q): %v", in[:], err)
+		}
+		if got != want {
+			t.Fatalf("Normalize(%q) = %q, want %q", in[:], got[:], want)
+		}
+	}
+}
+
+func TestNormalizeRejectsInterior(t *testing.T) {
+	for _, in := range []YULID{
+		{'J', 'N', 'D', 'E', '-', 'A', ' ', '1', '2', '3'},
+		{'J', 'N', 'D', 'E', '-', 'A', 0, '1', '2', '3'},
+		{'J', 'N', 'D', 'E', '-', 'A', 'b', '1', '2'},
+		{'J', 'N', 'D', 'E', '-', 'A', 'B', ' ', ' ', ' ', ' '},
+		{},
+	} {
+		if got, err := in.Normalize(); err == nil {
+			t.Errorf("Normalize(%q) = %q, want an error", in[:], got)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package yulid
+
+import "time"
+
+// RetryReason says why a candidate ID was discarded and regenerated
+type RetryReason int
+
+const (
+	RetryFiltered  RetryReason = iota + 1 // the SuffixFilter rejected the suffix
+	RetryCollision                        // the UniquenessChecker reported the ID taken
+	RetryDuplicate                        // the ID repeated one already in the same batch
+	RetryRejected                         // the WithValidator hook rejected the ID
+)
+
+func (r RetryReason) String() string {
+	switch r {
+	case RetryFiltered:
+		return "filtered"
+	case RetryCollision:
+		return "collision"
+	case RetryDuplicate:
+		return "duplicate"
+	case RetryRejected:
+		return "rejected"
+	default:
+		return "unknown"
+	}
+}
+
+// Observer receives events from a Generator, for metrics and tracing. Its
+// methods are called synchronously from the generating goroutine, possibly
+// from several goroutines at once, so they must be safe for concurrent use
+// and should return quickly.
+type Observer interface {
+	// OnGenerate is called when a call to New, NewContext, NewBatch or
+	// NewWithSuffix returns count IDs, taking elapsed in total
+	OnGenerate(prefix string, count int, elapsed time.Duration)
+
+	// OnRetry is called each time a candidate is discarded and regenerated
+	OnRetry(prefix string, reason RetryReason)
+
+	// OnError is called when one of those calls fails
+	OnError(prefix string, err error)
+}
+
+// WithObserver makes a Generator report to o. Without an observer no timing
+// is taken and generation costs nothing extra.
+func WithObserver(o Observer) Option {
+	return func(opts *options) {
+		opts.observer = o
+	}
+}
+
+// observe reports the outcome of a generating call that started at start
+func (g *Generator) observe(prefix string, start time.Time, count int, err error) {
+	if err != nil {
+		g.opts.observer.OnError(prefix, err)
+		return
+	}
+	g.opts.observer.OnGenerate(prefix, count, time.Since(start))
+}
+
+// retried records a regenerated candidate in the Observer, if any
+func (g *Generator) retried(prefix string, reason RetryReason) {
+	if g.opts.observer != nil {
+		g.opts.observer.OnRetry(prefix, reason)
+	}
+}
@@ -0,0 +1,194 @@
+package yulid
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// event is one call recorded by recordingObserver
+type event struct {
+	kind   string // "generate", "retry" or "error"
+	prefix string
+	count  int
+	reason RetryReason
+	err    error
+}
+
+// recordingObserver records every event it receives
+type recordingObserver struct {
+	mu     sync.Mutex
+	events []event
+}
+
+func (o *recordingObserver) record(e event) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.events = append(o.events, e)
+}
+
+func (o *recordingObserver) OnGenerate(prefix string, count int, elapsed time.Duration) {
+	o.record(event{kind: "generate", prefix: prefix, count: count})
+}
+
+func (o *recordingObserver) OnRetry(prefix string, reason RetryReason) {
+	o.record(event{kind: "retry", prefix: prefix, reason: reason})
+}
+
+func (o *recordingObserver) OnError(prefix string, err error) {
+	o.record(event{kind: "error", prefix: prefix, err: err})
+}
+
+// take returns the events recorded so far and forgets them
+func (o *recordingObserver) take() []event {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	events := o.events
+	o.events = nil
+	return events
+}
+
+func TestObserverGenerate(t *testing.T) {
+	obs := &recordingObserver{}
+	g, err := NewGenerator(WithObserver(obs))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := g.NewContext(context.Background(), "JNDE"); err != nil {
+		t.Fatal(err)
+	}
+	if got := obs.take(); len(got) != 1 || got[0] != (event{kind: "generate", prefix: "JNDE", count: 1}) {
+		t.Errorf("NewContext events = %+v, want one generate of 1", got)
+	}
+
+	if _, err := g.NewBatch("ACME", 25); err != nil {
+		t.Fatal(err)
+	}
+	if got := obs.take(); len(got) != 1 || got[0] != (event{kind: "generate", prefix: "ACME", count: 25}) {
+		t.Errorf("NewBatch events = %+v, want one generate of 25", got)
+	}
+
+	if _, err := g.NewWithSuffix("JNDE", "GOLD1"); err != nil {
+		t.Fatal(err)
+	}
+	if got := obs.take(); len(got) != 1 || got[0].kind != "generate" || got[0].count != 1 {
+		t.Errorf("NewWithSuffix events = %+v, want one generate of 1", got)
+	}
+}
+
+func TestObserverError(t *testing.T) {
+	obs := &recordingObserver{}
+	g, err := NewGenerator(WithObserver(obs), WithAlphabet("AB"), WithSuffixLength(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+		want error
+	}{
+		{"NewContext", func() error { _, err := g.NewContext(context.Background(), "jn"); return err }, ErrorInvalidInput},
+		{"NewBatch", func() error { _, err := g.NewBatch("JNDE", 17); return err }, ErrExhausted},
+		{"NewWithSuffix", func() error { _, err := g.NewWithSuffix("JNDE", "ABCD"); return err }, ErrInvalidSuffix},
+	}
+	for _, tt := range tests {
+		if err := tt.call(); !errors.Is(err, tt.want) {
+			t.Fatalf("%s: err = %v, want %v", tt.name, err, tt.want)
+		}
+		got := obs.take()
+		if len(got) != 1 || got[0].kind != "error" || !errors.Is(got[0].err, tt.want) {
+			t.Errorf("%s: events = %+v, want one error matching %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// onceChecker reports the first ID it is asked about as taken
+type onceChecker struct{ first once }
+
+func (c *onceChecker) Exists(context.Context, YULID) (bool, error) {
+	return c.first.next(), nil
+}
+
+// once reports true from its first call to next only
+type once struct{ done bool }
+
+func (o *once) next() bool {
+	first := !o.done
+	o.done = true
+	return first
+}
+
+func TestObserverRetried(t *testing.T) {
+	tests := []struct {
+		reason RetryReason
+		opt    func() Option // rejects the first candidate only
+	}{
+		{RetryFiltered, func() Option {
+			var first once
+			return WithSuffixFilter(SuffixFilterFunc(func(string, string) bool { return first.next() }))
+		}},
+		{RetryRejected, func() Option {
+			var first once
+			return WithValidator(func(YULID) error {
+				if first.next() {
+					return errors.New("rejected")
+				}
+				return nil
+			})
+		}},
+		{RetryCollision, func() Option { return WithUniquenessChecker(&onceChecker{}) }},
+	}
+	for _, tt := range tests {
+		obs := &recordingObserver{}
+		g, err := NewGenerator(WithObserver(obs), tt.opt())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := g.New("JNDE"); err != nil {
+			t.Fatalf("%v: %v", tt.reason, err)
+		}
+		want := []event{{kind: "retry", prefix: "JNDE", reason: tt.reason}, {kind: "generate", prefix: "JNDE", count: 1}}
+		if got := obs.take(); len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+			t.Errorf("%v: events = %+v, want %+v", tt.reason, got, want)
+		}
+	}
+
+	// a bulk draw of the whole 16-ID keyspace all but certainly repeats one
+	obs := &recordingObserver{}
+	g, err := NewGenerator(WithObserver(obs), WithAlphabet("AB"), WithSuffixLength(4), WithMaxRetries(1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.NewBatch("JNDE", 16); err != nil {
+		t.Fatal(err)
+	}
+	events := obs.take()
+	duplicates := 0
+	for _, e := range events[:len(events)-1] {
+		if e.kind != "retry" || e.reason != RetryDuplicate {
+			t.Fatalf("NewBatch event %+v, want only duplicate retries before the result", e)
+		}
+		duplicates++
+	}
+	if duplicates == 0 || events[len(events)-1] != (event{kind: "generate", prefix: "JNDE", count: 16}) {
+		t.Errorf("NewBatch events = %+v, want duplicates then one generate of 16", events)
+	}
+}
+
+func TestRetryReasonString(t *testing.T) {
+	for reason, want := range map[RetryReason]string{
+		RetryFiltered:  "filtered",
+		RetryCollision: "collision",
+		RetryDuplicate: "duplicate",
+		RetryRejected:  "rejected",
+		RetryReason(0): "unknown",
+	} {
+		if got := reason.String(); got != want {
+			t.Errorf("RetryReason(%d).String() = %q, want %q", int(reason), got, want)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+package yulid
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidOption = errors.New("invalid YULID option")
+)
+
+// Option configures a Generator, or a single call to New, Parse or Validate.
+// The same format options must be used when validating an ID as when
+// generating it.
+type Option func(*options)
+
+// options is the resolved format configuration
+type options struct {
+	suffixLen  int       // 0 accepts any length from minSuffixLen to maxSuffixLen; New then uses maxSuffixLen
+	separator  byte      // separator between prefix and suffix
+	alphabet   string    // characters the suffix is drawn from
+	entropy    io.Reader // randomness source; nil uses pooled crypto/rand readers
+	retry      RetryPolicy
+	retries    int // regeneration limit; 0 uses defaultMaxRetries
+	checker    UniquenessChecker
+	checksum   bool // last suffix character is a check character
+	depth      bool // suffix ends with a depth marker, before any check character
+	normalize  bool // rewrite misread suffix characters before validating
+	filter     SuffixFilter
+	reserved   map[string]struct{}
+	observer   Observer
+	version    int   // pinned format version; 0 if unpinned
+	versionErr error // set by WithFormatVersion for an unregistered version
+
+	transliterator Transliterator
+	derivationKey  []byte         // HMAC key for FromUUID, FromUint64 and FromName
+	salt           []byte         // mixed into derivations; nil leaves them unsalted
+	strategy       SuffixStrategy // nil draws from entropy
+
+	optionalSeparator bool   // Parse inserts a missing separator
+	noRepeats         bool   // suffixes must not repeat a character twice in a row
+	firstSet          string // characters the first suffix character is drawn from; "" means the alphabet
+	validator         func(YULID) error
+}
+
+// RetryPolicy controls how generation responds to entropy read failures. A
+// failed read is retried up to Attempts-1 more times, sleeping Backoff before
+// the first retry and doubling the delay each time after. The zero value makes
+// a single attempt.
+type RetryPolicy struct {
+	Attempts int
+	Backoff  time.Duration
+}
+
+// WithSuffixLength sets the suffix length, between 4 and 6. New generates
+// suffixes of exactly n characters and Validate requires exactly n; without the
+// option New generates 6 and Validate accepts 4-6.
+func WithSuffixLength(n int) Option {
+	return func(o *options) {
+		o.suffixLen = n
+	}
+}
+
+// WithSeparator sets the separator between prefix and suffix, '-' by default.
+// It must be a printable ASCII character outside the alphabet.
+func WithSeparator(sep byte) Option {
+	return func(o *options) {
+		o.separator = sep
+	}
+}
+
+// WithAlphabet sets the characters suffixes are drawn from, A-Z and 0-9 by
+// default. It must hold at least two distinct printable ASCII characters.
+// Prefixes are always A-Z and 0-9.
+func WithAlphabet(alphabet string) Option {
+	return func(o *options) {
+		o.alphabet = alphabet
+	}
+}
+
+// AllowMissingSeparator makes Parse accept IDs stored without their
+// separator, such as "JNDEED24HS", by inserting it after the 4-character
+// prefix before validating. Input that has the separator parses as usual.
+// Without this option, the default, the separator is required. Error
+// indices for separator-less input refer to the ID with the separator
+// inserted.
+func AllowMissingSeparator() Option {
+	return func(o *options) {
+		o.optionalSeparator = true
+	}
+}
+
+// WithEntropy sets the source of randomness for generated suffixes, which is
+// crypto/rand by default. Tests can pass a seeded reader for reproducible IDs;
+// production code should keep the default.
+func WithEntropy(r io.Reader) Option {
+	return func(o *options) {
+		o.entropy = r
+	}
+}
+
+// WithRetryPolicy sets how entropy read failures are retried. By default a
+// failure is returned immediately as ErrEntropy.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(o *options) {
+		o.retry = p
+	}
+}
+
+// WithMaxRetries sets how many times generation may regenerate a rejected
+// candidate, such as a suffix the UniquenessChecker reports as taken, before
+// giving up with ErrExhausted. n must not be negative, and 0 means the
+// default of 100, so every Generator allows at least one retry. Given to
+// Configure, it also bounds the package-level helpers that regenerate IDs,
+// such as NewExcluding and WriteBatch.
+func WithMaxRetries(n int) Option {
+	return func(o *options) {
+		o.retries = n
+	}
+}
+
+// WithUniquenessChecker makes generation consult c and regenerate IDs that
+// already exist.
+func WithUniquenessChecker(c UniquenessChecker) Option {
+	return func(o *options) {
+		o.checker = c
+	}
+}
+
+// WithReservedPrefixes makes generation reject the given prefixes with
+// ErrReservedPrefix. Passing DefaultReservedPrefixes blocks the common
+// problem cases; the option may be repeated to add more.
+func WithReservedPrefixes(prefixes ...string) Option {
+	return func(o *options) {
+		reserved := make(map[string]struct{}, len(o.reserved)+len(prefixes))
+		for p := range o.reserved {
+			reserved[p] = struct{}{}
+		}
+		for _, p := range prefixes {
+			reserved[p] = struct{}{}
+		}
+		o.reserved = reserved
+	}
+}
+
+// defaultOptions returns the standard format
+func defaultOptions() options {
+	return options{
+		separator:      '-',
+		alphabet:       alphanumeric,
+		transliterator: LatinFolding{},
+	}
+}
+
+// resolveOptions applies opts over the defaults and checks the result
+func resolveOptions(opts []Option) (options, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.versionErr != nil {
+		return options{}, o.versionErr
+	}
+	if o.suffixLen != 0 && (o.suffixLen < minSuffixLen || o.suffixLen > maxSuffixLen) {
+		return options{}, fmt.Errorf("%w: suffix length %d is outside %d-%d", ErrInvalidOption, o.suffixLen, minSuffixLen, maxSuffixLen)
+	}
+	if !isPrintableASCII(o.separator) || isAlphanumeric(rune(o.separator)) {
+		return options{}, fmt.Errorf("%w: separator %q must be printable ASCII and not alphanumeric", ErrInvalidOption, o.separator)
+	}
+	if o.retries < 0 {
+		return options{}, fmt.Errorf("%w: max retries must not be negative", ErrInvalidOption)
+	}
+	if o.retry.Attempts < 0 || o.retry.Backoff < 0 {
+		return options{}, fmt.Errorf("%w: retry policy must not be negative", ErrInvalidOption)
+	}
+	if o.transliterator == nil {
+		return options{}, fmt.Errorf("%w: transliterator must not be nil", ErrInvalidOption)
+	}
+	if len(o.alphabet) < 2 {
+		return options{}, fmt.Errorf("%w: alphabet must have at least two characters", ErrInvalidOption)
+	}
+	for i := 0; i < len(o.alphabet); i++ {
+		c := o.alphabet[i]
+		if !isPrintableASCII(c) || c == o.separator || strings.IndexByte(o.alphabet[i+1:], c) >= 0 {
+			return options{}, fmt.Errorf("%w: alphabet character %q is repeated, unprintable or the separator", ErrInvalidOption, c)
+		}
+	}
+	if o.firstSet != "" {
+		if o.strategy != nil {
+			return options{}, fmt.Errorf("%w: a first-character set cannot be combined with a suffix strategy", ErrInvalidOption)
+		}
+		for i := 0; i < len(o.firstSet); i++ {
+			c := o.firstSet[i]
+			if strings.IndexByte(o.alphabet, c) < 0 || strings.IndexByte(o.firstSet[i+1:], c) >= 0 {
+				return options{}, fmt.Errorf("%w: first-character set character %q is repeated or outside the alphabet", ErrInvalidOption, c)
+			}
+		}
+	}
+	if h, ok := o.strategy.(interface{ timeLen(string) int }); ok && h.timeLen(o.alphabet) >= o.randomLen() {
+		return options{}, fmt.Errorf("%w: hybrid timestamp of %d characters leaves no room for random ones", ErrInvalidOption, h.timeLen(o.alphabet))
+	}
+
+	return o, nil
+}
+
+// generatedSuffixLen is the suffix length New produces under o
+func (o options) generatedSuffixLen() int {
+	if o.suffixLen == 0 {
+		return maxSuffixLen
+	}
+	return o.suffixLen
+}
+
+// randomLen is the number of random characters in a generated suffix
+func (o options) randomLen() int {
+	n := o.generatedSuffixLen()
+	if o.checksum {
+		n--
+	}
+	if o.depth {
+		n--
+	}
+	return n
+}
+
+// keyspace is the number of distinct random parts New can produce under o
+func (o options) keyspace() float64 {
+	if o.firstSet != "" {
+		return float64(len(o.firstSet)) * math.Pow(float64(len(o.alphabet)), float64(o.randomLen()-1))
+	}
+	return math.Pow(float64(len(o.alphabet)), float64(o.randomLen()))
+}
+
+// writeSuffix writes random into id after the separator, followed by a root
+// depth marker and then its check character when those are enabled
+func (o options) writeSuffix(id *YULID, random []byte) {
+	start := prefixLen + separatorLen
+	end := start + copy(id[start:], random)
+	if o.depth {
+		id[end] = o.alphabet[0]
+		end++
+	}
+	if o.checksum {
+		id[end] = checkChar(o.alphabet, id[start:end])
+	}
+}
+
+// maxRetries is the regeneration limit under o
+func (o options) maxRetries() int {
+	if o.retries == 0 {
+		return defaultMaxRetries
+	}
+	return o.retries
+}
+
+// random fills dst with random characters from alphabet, retrying entropy
+// failures according to the retry policy
+func (o options) random(dst []byte, alphabet string) error {
+	backoff := o.retry.Backoff
+	for attempt := 1; ; attempt++ {
+		err := generateRandom(dst, o.entropy, alphabet)
+		if err == nil || attempt >= o.retry.Attempts {
+			return err
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// isPrintableASCII reports whether b is a printable, non-space ASCII character
+func isPrintableASCII(b byte) bool {
+	return b > ' ' && b < 0x7f
+}
@@ -0,0 +1,72 @@
+package yulid
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+)
+
+// PackSuffix encodes the suffix of yd as a base-36 integer, using each
+// character's position in the standard A-Z0-9 alphabet as its digit. It
+// returns false if yd is not a valid YULID in the standard format, whatever
+// Configure has set; the packed form is fixed.
+//
+// The suffix length is not part of the packed value ("AAAA" and "AAAAAA" both
+// pack to 0), so it must be stored alongside when lengths vary.
+func (yd YULID) PackSuffix() (uint64, bool) {
+	if standardGenerator.Validate(yd) != nil {
+		return 0, false
+	}
+
+	var n uint64
+	for _, c := range yd.Suffix() {
+		n = n*uint64(len(alphanumeric)) + uint64(strings.IndexRune(alphanumeric, c))
+	}
+	return n, true
+}
+
+// UnpackSuffix is the inverse of PackSuffix, returning the length-character
+// suffix encoded by n.
+func UnpackSuffix(n uint64, length int) (string, error) {
+	if length < minSuffixLen || length > maxSuffixLen {
+		return "", errors.New("YULID suffix length is out of range")
+	}
+
+	return unpackBase36(n, length)
+}
+
+// unpackBase36 returns the length-character base-36 string encoded by n,
+// using the alphabet positions of alphanumeric as digits
+func unpackBase36(n uint64, length int) (string, error) {
+	base := uint64(len(alphanumeric))
+	s := make([]byte, length)
+	for i := length - 1; i >= 0; i-- {
+		s[i] = alphanumeric[n%base]
+		n /= base
+	}
+	if n != 0 {
+		return "", errors.New("packed value does not fit in the requested length")
+	}
+
+	return string(s), nil
+}
+
+// SuffixOrdinal returns the position of yd's suffix in the enumeration of all
+// suffixes of the same length, i.e. its base-36 value. Since 'A' is the zero
+// digit, ordinals are only comparable between suffixes of the same length.
+func (yd YULID) SuffixOrdinal() (*big.Int, error) {
+	n, ok := yd.PackSuffix()
+	if !ok {
+		return nil, standardGenerator.Validate(yd)
+	}
+	return new(big.Int).SetUint64(n), nil
+}
+
+// SuffixFromOrdinal is the inverse of SuffixOrdinal, returning the
+// length-character suffix at position n.
+func SuffixFromOrdinal(n *big.Int, length int) (string, error) {
+	if n.Sign() < 0 || !n.IsUint64() {
+		return "", errors.New("suffix ordinal is out of range")
+	}
+	return UnpackSuffix(n.Uint64(), length)
+}
@@ -0,0 +1,102 @@
+package yulid
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestPackSuffixRoundTrip(t *testing.T) {
+	for length := minSuffixLen; length <= maxSuffixLen; length++ {
+		generated, err := New("JNDE", WithSuffixLength(length))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, id := range []YULID{
+			MustParse("JNDE-" + strings.Repeat("A", length)),
+			MustParse("JNDE-" + strings.Repeat("9", length)),
+			generated,
+		} {
+			n, ok := id.PackSuffix()
+			if !ok {
+				t.Fatalf("PackSuffix(%q) failed", id)
+			}
+			s, err := UnpackSuffix(n, length)
+			if err != nil {
+				t.Fatalf("UnpackSuffix(%d, %d): %v", n, length, err)
+			}
+			if s != id.Suffix() {
+				t.Fatalf("UnpackSuffix(PackSuffix(%q)) = %q", id, s)
+			}
+		}
+	}
+}
+
+func TestPackSuffixValues(t *testing.T) {
+	tests := []struct {
+		id   string
+		want uint64
+	}{
+		{"JNDE-AAAAAA", 0},
+		{"JNDE-AAAAAB", 1},
+		{"JNDE-AAAA9", 35},
+		{"JNDE-AABA", 36},
+		{"JNDE-999999", 2176782335}, // 36^6 - 1
+	}
+	for _, tt := range tests {
+		if got, ok := MustParse(tt.id).PackSuffix(); !ok || got != tt.want {
+			t.Errorf("PackSuffix(%q) = %d, %v, want %d", tt.id, got, ok, tt.want)
+		}
+	}
+}
+
+func TestPackSuffixInvalid(t *testing.T) {
+	if _, ok := (YULID{}).PackSuffix(); ok {
+		t.Error("PackSuffix succeeded on the zero YULID")
+	}
+	if _, err := UnpackSuffix(0, 3); err == nil {
+		t.Error("UnpackSuffix accepted length 3")
+	}
+	if _, err := UnpackSuffix(36*36*36*36, 4); err == nil {
+		t.Error("UnpackSuffix accepted a value too large for the length")
+	}
+}
+
+func TestSuffixOrdinalRoundTrip(t *testing.T) {
+	tests := []struct {
+		id   string
+		want int64
+	}{
+		{"JNDE-AAAA", 0},
+		{"JNDE-AAAAAA", 0}, // leading zero digits do not shift the ordinal
+		{"JNDE-AAAB", 1},
+		{"JNDE-BAAA", 36 * 36 * 36},
+		{"JNDE-9999", 36*36*36*36 - 1},
+	}
+	for _, tt := range tests {
+		id := MustParse(tt.id)
+		n, err := id.SuffixOrdinal()
+		if err != nil {
+			t.Fatalf("SuffixOrdinal(%q): %v", id, err)
+		}
+		if n.Int64() != tt.want {
+			t.Errorf("SuffixOrdinal(%q) = %v, want %d", id, n, tt.want)
+		}
+		s, err := SuffixFromOrdinal(n, len(id.Suffix()))
+		if err != nil {
+			t.Fatalf("SuffixFromOrdinal(%v, %d): %v", n, len(id.Suffix()), err)
+		}
+		if s != id.Suffix() {
+			t.Errorf("SuffixFromOrdinal(SuffixOrdinal(%q)) = %q", id, s)
+		}
+	}
+
+	for _, n := range []*big.Int{big.NewInt(-1), new(big.Int).Lsh(big.NewInt(1), 64)} {
+		if _, err := SuffixFromOrdinal(n, 6); err == nil {
+			t.Errorf("SuffixFromOrdinal(%v) succeeded", n)
+		}
+	}
+	if _, err := (YULID{}).SuffixOrdinal(); err == nil {
+		t.Error("SuffixOrdinal succeeded on the zero YULID")
+	}
+}
@@ -0,0 +1,120 @@
+package yulid
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrProfileMismatch = errors.New("YULID does not match the expected profile")
+	ErrFutureTimestamp = errors.New("YULID timestamp is in the future")
+)
+
+// Profile names a family of YULID formats for MatchesProfile
+type Profile int
+
+const (
+	// ProfilePlain is the format described by the options passed to
+	// MatchesProfile, or the default format without any
+	ProfilePlain Profile = iota + 1
+
+	// ProfileChecksummed is the format described by the options with
+	// WithChecksum added, so the check character must verify
+	ProfileChecksummed
+
+	// ProfileTimed is the NewSortable layout; its timestamp must decode to a
+	// time no later than the current hour
+	ProfileTimed
+
+	// ProfileTemporary is the NewTemporary layout; its expiry must not have
+	// passed
+	ProfileTemporary
+
+	// ProfileVersioned is any format registered with RegisterFormatVersion
+	ProfileVersioned
+)
+
+var profileNames = map[Profile]string{
+	ProfilePlain:       "plain",
+	ProfileChecksummed: "checksummed",
+	ProfileTimed:       "timed",
+	ProfileTemporary:   "temporary",
+	ProfileVersioned:   "versioned",
+}
+
+// String returns the lowercase name of p, such as "checksummed"
+func (p Profile) String() string {
+	if name, ok := profileNames[p]; ok {
+		return name
+	}
+	return fmt.Sprintf("Profile(%d)", int(p))
+}
+
+// ProfileError reports that a YULID failed the checks of a Profile. It matches
+// ErrProfileMismatch with errors.Is, and Err, the reason the checks failed,
+// can be matched too.
+type ProfileError struct {
+	Profile Profile // the profile the YULID was checked against
+	Err     error   // why the YULID does not match it
+}
+
+func (e *ProfileError) Error() string {
+	return fmt.Sprintf("YULID does not match the %v profile: %v", e.Profile, e.Err)
+}
+
+func (e *ProfileError) Unwrap() []error {
+	return []error{ErrProfileMismatch, e.Err}
+}
+
+// MatchesProfile runs the structural checks specific to p against yd and
+// returns a *ProfileError if they fail. opts describe the format for
+// ProfilePlain and ProfileChecksummed, as for Validate. The timed and
+// temporary layouts are fixed to the standard format and versioned IDs are
+// checked against the registry, so those profiles take no options.
+//
+// Timed and temporary IDs are ordinary 6-character IDs whose leading
+// characters are read as a time, so any standard ID passes the structural
+// part of those checks; the time bound is what tells them apart. There is no
+// signed profile, since the package has no signed format.
+func (yd YULID) MatchesProfile(p Profile, opts ...Option) error {
+	if len(opts) > 0 && p != ProfilePlain && p != ProfileChecksummed {
+		return fmt.Errorf("%w: the %v profile takes no options", ErrInvalidOption, p)
+	}
+
+	var err error
+	switch p {
+	case ProfilePlain:
+		err = Validate(yd, opts...)
+	case ProfileChecksummed:
+		err = Validate(yd, append(opts[:len(opts):len(opts)], WithChecksum())...)
+	case ProfileTimed:
+		err = matchTimed(yd)
+	case ProfileTemporary:
+		err = ValidateAt(yd, timeNow())
+	case ProfileVersioned:
+		_, err = ValidateVersioned(yd)
+	default:
+		return fmt.Errorf("%w: unknown profile %v", ErrInvalidOption, p)
+	}
+
+	if err != nil {
+		return &ProfileError{Profile: p, Err: err}
+	}
+	return nil
+}
+
+// matchTimed checks that yd is in the NewSortable layout with a timestamp no
+// later than the current time
+func matchTimed(yd YULID) error {
+	if err := standardGenerator.Validate(yd); err != nil {
+		return err
+	}
+	t, ok := Timestamp(yd)
+	if !ok {
+		return ErrInvalidLength
+	}
+	if t.After(timeNow()) {
+		return ErrFutureTimestamp
+	}
+	return nil
+}
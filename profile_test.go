@@ -0,0 +1,110 @@
+package yulid
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestMatchesProfile(t *testing.T) {
+	defer func(now func() time.Time) { timeNow = now }(timeNow)
+	now := time.Date(2026, time.March, 14, 15, 9, 26, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+
+	const version = 91
+	if !slices.Contains(FormatVersions(), version) {
+		if err := RegisterFormatVersion(version, WithSeparator('~')); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	checked, err := New("JNDE", WithChecksum())
+	if err != nil {
+		t.Fatal(err)
+	}
+	badCheck := checked
+	if last := maxLen - 1; badCheck[last] == 'A' {
+		badCheck[last] = 'B'
+	} else {
+		badCheck[last] = 'A'
+	}
+	timed, err := NewSortableAt("JNDE", now.Add(-48*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	future, err := NewSortableAt("JNDE", now.Add(2*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	temporary, err := NewTemporary("JNDE", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expired, err := NewSortableAt("JNDE", now.Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	versioned, err := New("JNDE", WithFormatVersion(version))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		id      YULID
+		profile Profile
+		opts    []Option
+		want    error // nil for a match
+	}{
+		{"plain", MustParse("JNDE-AB12CD"), ProfilePlain, nil, nil},
+		{"plain with options", MustParse("JNDE.AB12", WithSeparator('.')), ProfilePlain, []Option{WithSeparator('.')}, nil},
+		{"plain wrong separator", MustParse("JNDE.AB12", WithSeparator('.')), ProfilePlain, nil, ErrInvalidSeparator},
+		{"checksummed", checked, ProfileChecksummed, nil, nil},
+		{"checksummed bad check", badCheck, ProfileChecksummed, nil, ErrInvalidChecksum},
+		{"timed", timed, ProfileTimed, nil, nil},
+		{"timed in the future", future, ProfileTimed, nil, ErrFutureTimestamp},
+		{"timed too short", MustParse("JNDE-AB12"), ProfileTimed, nil, ErrInvalidLength},
+		{"temporary", temporary, ProfileTemporary, nil, nil},
+		{"temporary expired", expired, ProfileTemporary, nil, ErrExpired},
+		{"versioned", versioned, ProfileVersioned, nil, nil},
+		{"versioned standard", MustParse("JNDE-AB12CD"), ProfileVersioned, nil, nil},
+		{"versioned unknown", YULID{'J', 'N', 'D', 'E', '#', 'A', 'B', '1', '2'}, ProfileVersioned, nil, ErrInvalidSeparator},
+	}
+	for _, tt := range tests {
+		err := tt.id.MatchesProfile(tt.profile, tt.opts...)
+		if tt.want == nil {
+			if err != nil {
+				t.Errorf("%s: MatchesProfile(%q, %v): %v", tt.name, tt.id, tt.profile, err)
+			}
+			continue
+		}
+
+		var pe *ProfileError
+		if !errors.As(err, &pe) || pe.Profile != tt.profile {
+			t.Errorf("%s: MatchesProfile(%q, %v): err = %v, want a *ProfileError for the profile", tt.name, tt.id, tt.profile, err)
+			continue
+		}
+		if !errors.Is(err, ErrProfileMismatch) || !errors.Is(err, tt.want) {
+			t.Errorf("%s: MatchesProfile(%q, %v): err = %v, want ErrProfileMismatch and %v", tt.name, tt.id, tt.profile, err, tt.want)
+		}
+	}
+}
+
+func TestMatchesProfileRejectsMisuse(t *testing.T) {
+	id := MustParse("JNDE-AB12CD")
+	for _, p := range []Profile{ProfileTimed, ProfileTemporary, ProfileVersioned} {
+		if err := id.MatchesProfile(p, WithSuffixLength(6)); !errors.Is(err, ErrInvalidOption) {
+			t.Errorf("MatchesProfile(%v) with options: err = %v, want ErrInvalidOption", p, err)
+		}
+	}
+	if err := id.MatchesProfile(Profile(0)); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("MatchesProfile(Profile(0)): err = %v, want ErrInvalidOption", err)
+	}
+	if got := Profile(42).String(); got != "Profile(42)" {
+		t.Errorf("Profile(42).String() = %q", got)
+	}
+	if got := ProfileChecksummed.String(); got != "checksummed" {
+		t.Errorf("ProfileChecksummed.String() = %q", got)
+	}
+}
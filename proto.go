@@ -0,0 +1,19 @@
+package yulid
+
+// Proto returns the wire form of yd for protobuf string fields. It is the
+// canonical string form.
+//
+// Conversion layers between generated messages and domain types should use
+// Proto and FromProto rather than String and ad-hoc parsing, so that every wire
+// value is validated at the boundary:
+//
+//	msg.CustomerId = customer.ID.Proto()
+//	id, err := FromProto(msg.CustomerId)
+func (yd YULID) Proto() string {
+	return yd.String()
+}
+
+// FromProto converts a protobuf string field back into a YULID, validating it
+func FromProto(s string) (YULID, error) {
+	return Parse(s)
+}
@@ -0,0 +1,28 @@
+package yulid
+
+import "testing"
+
+func TestProtoRoundTrip(t *testing.T) {
+	for _, s := range []string{"JNDE-AB12", "JNDE-AB12C", "JNDE-AB12CD"} {
+		id := MustParse(s)
+		if id.Proto() != s {
+			t.Fatalf("Proto(%q) = %q", id, id.Proto())
+		}
+		got, err := FromProto(id.Proto())
+		if err != nil {
+			t.Fatalf("FromProto(%q): %v", id.Proto(), err)
+		}
+		if got != id {
+			t.Fatalf("FromProto(%q) = %q, want %q", id.Proto(), got, id)
+		}
+	}
+}
+
+func TestFromProtoRejectsInvalid(t *testing.T) {
+	// proto3 strings default to "", so an unset field must not parse
+	for _, s := range []string{"", "jnde-ab12cd", "JNDE_AB12CD", "JNDE-AB12CDE", " JNDE-AB12CD"} {
+		if id, err := FromProto(s); err == nil {
+			t.Errorf("FromProto(%q) = %q, want an error", s, id)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package yulid
+
+import (
+	"errors"
+	"strings"
+)
+
+// qrAlphanumeric is the character set of the QR code alphanumeric mode
+const qrAlphanumeric = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+// QRPayload returns the canonical form of yd for encoding in QR alphanumeric
+// mode. It returns an error if yd contains a character outside that mode, such
+// as a lowercase letter.
+func (yd YULID) QRPayload() (string, error) {
+	s := yd.String()
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(qrAlphanumeric, s[i]) < 0 {
+			return "", errors.New("YULID contains characters outside the QR alphanumeric set")
+		}
+	}
+	return s, nil
+}
@@ -0,0 +1,25 @@
+package yulid
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDefaultFormatIsQRAlphanumeric(t *testing.T) {
+	for _, c := range []byte(alphanumeric + "-") {
+		if strings.IndexByte(qrAlphanumeric, c) < 0 {
+			t.Errorf("default format character %q is outside QR alphanumeric mode", c)
+		}
+	}
+}
+
+func TestQRPayload(t *testing.T) {
+	id := MustParse("JNDE-ED24HS")
+	if got, err := id.QRPayload(); err != nil || got != "JNDE-ED24HS" {
+		t.Fatalf("QRPayload(%q) = %q, %v", id, got, err)
+	}
+	lower := YULID{'j', 'n', 'd', 'e', '-', 'e', 'd', '2', '4'}
+	if _, err := lower.QRPayload(); err == nil {
+		t.Fatalf("QRPayload(%q) accepted lowercase", lower)
+	}
+}
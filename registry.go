@@ -0,0 +1,106 @@
+package yulid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	ErrUnknownTenant = errors.New("no YULID generator is registered for the tenant")
+)
+
+// Registry maps tenant keys to Generators, so a multi-tenant service can give
+// each tenant its own alphabet, suffix length, reserved prefixes and
+// UniquenessChecker. It is safe for concurrent use. The zero value is an
+// empty Registry ready to use.
+//
+// Request handlers usually resolve the tenant once, in middleware, and mint
+// through the context from then on:
+//
+//	ctx, err := registry.Context(r.Context(), tenantID)
+//	...
+//	id, err := yulid.FromContext(ctx).New("JNDE")
+type Registry struct {
+	mu         sync.RWMutex
+	generators map[string]*Generator
+}
+
+// Register creates a Generator from opts for tenant. It is an error to
+// register a tenant twice; use Replace to change a tenant's configuration.
+func (r *Registry) Register(tenant string, opts ...Option) (*Generator, error) {
+	return r.set(tenant, false, opts)
+}
+
+// Replace sets tenant's Generator to one created from opts, whether or not
+// the tenant was registered. Generators already handed out keep their old
+// configuration.
+func (r *Registry) Replace(tenant string, opts ...Option) (*Generator, error) {
+	return r.set(tenant, true, opts)
+}
+
+// set creates tenant's Generator, failing if one exists unless replace is set
+func (r *Registry) set(tenant string, replace bool, opts []Option) (*Generator, error) {
+	g, err := NewGenerator(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.generators[tenant]; ok && !replace {
+		return nil, fmt.Errorf("tenant %q is already registered", tenant)
+	}
+	if r.generators == nil {
+		r.generators = make(map[string]*Generator)
+	}
+	r.generators[tenant] = g
+	return g, nil
+}
+
+// Remove unregisters tenant
+func (r *Registry) Remove(tenant string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.generators, tenant)
+}
+
+// Generator returns the Generator registered for tenant, or ErrUnknownTenant
+func (r *Registry) Generator(tenant string) (*Generator, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	g, ok := r.generators[tenant]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownTenant, tenant)
+	}
+	return g, nil
+}
+
+// Context returns a copy of ctx carrying tenant's Generator, for FromContext
+func (r *Registry) Context(ctx context.Context, tenant string) (context.Context, error) {
+	g, err := r.Generator(tenant)
+	if err != nil {
+		return nil, err
+	}
+	return ContextWithGenerator(ctx, g), nil
+}
+
+// generatorKey is the context key for the Generator set by ContextWithGenerator
+type generatorKey struct{}
+
+// ContextWithGenerator returns a copy of ctx carrying g, for FromContext
+func ContextWithGenerator(ctx context.Context, g *Generator) context.Context {
+	return context.WithValue(ctx, generatorKey{}, g)
+}
+
+// FromContext returns the Generator carried by ctx, or the default Generator
+// used by the package-level functions if there is none.
+func FromContext(ctx context.Context) *Generator {
+	if g, ok := ctx.Value(generatorKey{}).(*Generator); ok && g != nil {
+		return g
+	}
+	return defaultGenerator()
+}
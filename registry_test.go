@@ -0,0 +1,107 @@
+package yulid
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRegistryLookup(t *testing.T) {
+	var r Registry
+	acme, err := r.Register("acme", WithSeparator('.'), WithSuffixLength(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Register("globex", WithAlphabet(AlphabetHumanSafe)); err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := r.Generator("acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g != acme {
+		t.Fatal("Generator returned a different Generator than Register")
+	}
+	id := mustNew(t, g, "JNDE")
+	if id[prefixLen] != '.' || id.Len() != minLen {
+		t.Fatalf("acme minted %q, want its registered format", id)
+	}
+	if err := Validate(id, WithSeparator('.'), WithSuffixLength(4)); err != nil {
+		t.Fatalf("%q does not validate under acme's options: %v", id, err)
+	}
+
+	if _, err := r.Generator("initech"); !errors.Is(err, ErrUnknownTenant) {
+		t.Errorf("Generator(initech): err = %v, want ErrUnknownTenant", err)
+	}
+	if _, err := r.Context(context.Background(), "initech"); !errors.Is(err, ErrUnknownTenant) {
+		t.Errorf("Context(initech): err = %v, want ErrUnknownTenant", err)
+	}
+}
+
+func TestRegistryReplace(t *testing.T) {
+	var r Registry
+	old, err := r.Register("acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Register("acme", WithSuffixLength(4)); err == nil {
+		t.Fatal("Register accepted a tenant twice")
+	}
+	if _, err := r.Register("globex", WithSuffixLength(9)); !errors.Is(err, ErrInvalidOption) {
+		t.Fatalf("Register with an invalid option: err = %v, want ErrInvalidOption", err)
+	}
+	if _, err := r.Generator("globex"); !errors.Is(err, ErrUnknownTenant) {
+		t.Fatal("a failed Register left the tenant registered")
+	}
+
+	replaced, err := r.Replace("acme", WithSuffixLength(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, _ := r.Generator("acme"); g != replaced || g == old {
+		t.Fatal("Replace did not swap the tenant's Generator")
+	}
+	if id := mustNew(t, old, "JNDE"); id.Len() != maxLen {
+		t.Fatalf("the replaced Generator changed format: %q", id)
+	}
+
+	r.Remove("acme")
+	if _, err := r.Generator("acme"); !errors.Is(err, ErrUnknownTenant) {
+		t.Fatalf("Generator after Remove: err = %v, want ErrUnknownTenant", err)
+	}
+	if _, err := r.Replace("acme"); err != nil {
+		t.Fatalf("Replace of an unregistered tenant: %v", err)
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	var r Registry
+	acme, err := r.Register("acme", WithSeparator('.'))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, err := r.Context(context.Background(), "acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g := FromContext(ctx); g != acme {
+		t.Fatal("FromContext did not return the tenant's Generator")
+	}
+
+	// without a Generator in the context, the default is used, including one
+	// set by Configure
+	if g := FromContext(context.Background()); g != defaultGenerator() {
+		t.Fatal("FromContext of an empty context is not the default Generator")
+	}
+	if g := FromContext(ContextWithGenerator(context.Background(), nil)); g != defaultGenerator() {
+		t.Fatal("FromContext of a nil Generator is not the default Generator")
+	}
+	defer Configure()
+	if err := Configure(WithSuffixLength(4)); err != nil {
+		t.Fatal(err)
+	}
+	if id := mustNew(t, FromContext(context.Background()), "JNDE"); id.Len() != minLen {
+		t.Fatalf("FromContext ignored Configure: minted %q", id)
+	}
+}
@@ -0,0 +1,32 @@
+package yulid
+
+import "errors"
+
+var (
+	ErrAdjacentRepeat = errors.New("YULID suffix repeats a character")
+)
+
+// WithNoAdjacentRepeats rejects suffixes in which two neighbouring characters
+// are equal, such as "AA" or "00", for readability. Generation regenerates
+// such candidates, counting them as filter rejections, and Validate reports
+// them with ErrAdjacentRepeat. A check character counts as part of the suffix.
+//
+// About 87% of 6-character suffixes qualify, (35/36)^5 of the keyspace, so
+// regeneration rarely takes more than one extra draw and stays within the
+// usual retry limit.
+func WithNoAdjacentRepeats() Option {
+	return func(o *options) {
+		o.noRepeats = true
+	}
+}
+
+// adjacentRepeat returns the index in suffix of the first character equal to
+// the one before it, or -1 if there is none
+func adjacentRepeat(suffix []byte) int {
+	for i := 1; i < len(suffix); i++ {
+		if suffix[i] == suffix[i-1] {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,48 @@
+package yulid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWithNoAdjacentRepeats(t *testing.T) {
+	g, err := NewGenerator(WithNoAdjacentRepeats(), WithAlphabet("ABC"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// with three characters only 96 of the 729 suffixes qualify, so rejection is frequent
+	for i := 0; i < 200; i++ {
+		id := mustNew(t, g, "JNDE")
+		if i := adjacentRepeat(id.SuffixBytes()); i >= 0 {
+			t.Fatalf("New returned %q, repeating at %d", id, i)
+		}
+	}
+	if g.Stats().FilterRejections == 0 {
+		t.Fatal("no rejections counted")
+	}
+
+	batch, err := g.NewBatch("JNDE", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range batch {
+		if adjacentRepeat(id.SuffixBytes()) >= 0 {
+			t.Fatalf("NewBatch returned %q", id)
+		}
+	}
+}
+
+func TestValidateNoAdjacentRepeats(t *testing.T) {
+	id := MustParse("JNDE-AB11CD")
+	var verr *ValidationError
+	if err := Validate(id, WithNoAdjacentRepeats()); !errors.As(err, &verr) || !errors.Is(err, ErrAdjacentRepeat) || verr.Index != 8 {
+		t.Fatalf("Validate(%q): err = %v, want ErrAdjacentRepeat at 8", id, err)
+	}
+	if err := Validate(id); err != nil {
+		t.Fatalf("Validate(%q) without the option: %v", id, err)
+	}
+	// the prefix may repeat
+	if err := Validate(MustParse("AABB-ABCDEF"), WithNoAdjacentRepeats()); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,24 @@
+package yulid
+
+import (
+	"errors"
+	"slices"
+)
+
+var (
+	ErrReservedPrefix = errors.New("prefix is reserved")
+)
+
+// DefaultReservedPrefixes are prefixes that collide with internal tooling or
+// read as placeholders, for use with WithReservedPrefixes. NewRandomPrefix never
+// produces them. The slice must not be modified.
+var DefaultReservedPrefixes = []string{
+	"ADMN", "ROOT", "SYST", "TEST", "DEMO", "TEMP", "NULL", "NONE", "NILL",
+	"VOID", "UNDF", "NAN0", "XXXX", "AAAA", "ZZZZ", "0000", "9999", "INTL",
+	"SUPP", "BOTS",
+}
+
+// isDefaultReserved reports whether prefix is in DefaultReservedPrefixes
+func isDefaultReserved(prefix string) bool {
+	return slices.Contains(DefaultReservedPrefixes, prefix)
+}
@@ -0,0 +1,94 @@
+package yulid
+
+import (
+	"hash/fnv"
+	"slices"
+	"strconv"
+	"sync"
+)
+
+// ringReplicas is the number of points each node occupies on a Ring
+const ringReplicas = 64
+
+// Ring is a consistent-hash ring that maps YULIDs to named nodes. Adding or
+// removing a node only moves the keys adjacent to its points on the ring.
+//
+// The zero value is an empty ring ready for use, and a Ring is safe for
+// concurrent use.
+type Ring struct {
+	mu     sync.RWMutex
+	points []uint64
+	owners map[uint64]string
+}
+
+// AddNode places name on the ring. Adding an existing node is a no-op.
+func (r *Ring) AddNode(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.owners == nil {
+		r.owners = make(map[uint64]string)
+	}
+	for i := 0; i < ringReplicas; i++ {
+		p := ringHash([]byte(name + "#" + strconv.Itoa(i)))
+		if _, ok := r.owners[p]; ok {
+			continue
+		}
+		r.owners[p] = name
+		r.points = append(r.points, p)
+	}
+	slices.Sort(r.points)
+}
+
+// RemoveNode takes name off the ring
+func (r *Ring) RemoveNode(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.points = slices.DeleteFunc(r.points, func(p uint64) bool {
+		if r.owners[p] != name {
+			return false
+		}
+		delete(r.owners, p)
+		return true
+	})
+}
+
+// Node returns the node responsible for yd, or "" if the ring is empty. Keys
+// are placed by a hash of the suffix.
+func (r *Ring) Node(yd YULID) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.points) == 0 {
+		return ""
+	}
+
+	h := ringHash(yd.SuffixBytes())
+	i, _ := slices.BinarySearch(r.points, h)
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.owners[r.points[i]]
+}
+
+// ringHash places b on the ring. FNV-1a of short, similar inputs such as
+// "node#1" and "node#2" differs mostly in its low bits, which would bunch a
+// node's points together, so the hash is passed through the murmur3 finalizer
+// to spread every input bit across the whole value.
+func ringHash(b []byte) uint64 {
+	h := hash64(b)
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
+
+// hash64 returns the 64-bit FNV-1a hash of b
+func hash64(b []byte) uint64 {
+	h := fnv.New64a()
+	h.Write(b)
+	return h.Sum64()
+}
@@ -0,0 +1,46 @@
+package yulid
+
+import "testing"
+
+func TestRingAddNodeMovesBoundedFraction(t *testing.T) {
+	ids, err := standardGenerator.NewBatch("JNDE", 10000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var r Ring
+	if got := r.Node(ids[0]); got != "" {
+		t.Fatalf("empty Ring.Node = %q, want \"\"", got)
+	}
+	for _, name := range []string{"a", "b", "c", "d"} {
+		r.AddNode(name)
+	}
+	before := make(map[YULID]string, len(ids))
+	for _, id := range ids {
+		before[id] = r.Node(id)
+	}
+
+	r.AddNode("e")
+	moved := 0
+	for _, id := range ids {
+		node := r.Node(id)
+		if node == before[id] {
+			continue
+		}
+		if node != "e" {
+			t.Fatalf("%q moved from %q to %q, not to the new node", id, before[id], node)
+		}
+		moved++
+	}
+	// the new node should take about a fifth of the keys
+	if frac := float64(moved) / float64(len(ids)); frac < 0.1 || frac > 0.3 {
+		t.Fatalf("adding a fifth node moved %.2f of the keys", frac)
+	}
+
+	r.RemoveNode("e")
+	for _, id := range ids {
+		if got := r.Node(id); got != before[id] {
+			t.Fatalf("after RemoveNode, %q maps to %q, want %q", id, got, before[id])
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package yulid
+
+import (
+	"iter"
+	"slices"
+)
+
+// Seq returns an iterator that yields freshly generated YULIDs for prefix, using
+// opts as for New, until the caller stops ranging:
+//
+//	for id, err := range Seq("JNDE") {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// The prefix is validated before the first yield; if it is invalid the
+// iterator yields a single zero YULID with the error and stops.
+func Seq(prefix string, opts ...Option) iter.Seq2[YULID, error] {
+	return func(yield func(YULID, error) bool) {
+		g, err := generatorFor(opts)
+		if err == nil {
+			err = g.checkPrefix(prefix)
+		}
+		if err != nil {
+			yield(YULID{}, err)
+			return
+		}
+		for {
+			id, err := g.New(prefix)
+			if !yield(id, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// EnumerateSuffixes returns an iterator over every suffix of the given length
+// over the alphabet of opts, resolved as for New, in lexicographic order so
+// that the suffixes come out sorted as strings: 0-9 before A-Z for the
+// default alphabet. That differs from the order SuffixOrdinal counts in,
+// which follows the alphabet's own order. Lengths from 1 to 6 are supported,
+// so small spaces such as 36 or 1,296 suffixes can be covered exhaustively in
+// tests even though only 4 to 6 characters form valid IDs; other lengths, and
+// invalid opts, yield nothing.
+//
+// The space grows as N^length for an alphabet of N characters: with the
+// default 36, about 1.7 million suffixes at length 4 and 2.2 billion at
+// length 6, so full enumeration is only practical for the shorter lengths.
+func EnumerateSuffixes(length int, opts ...Option) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		g, err := generatorFor(opts)
+		if err != nil || length < 1 || length > maxSuffixLen {
+			return
+		}
+		alphabet := []byte(g.opts.alphabet)
+		slices.Sort(alphabet)
+
+		digits := make([]int, length)
+		suffix := make([]byte, length)
+		for {
+			for i, d := range digits {
+				suffix[i] = alphabet[d]
+			}
+			if !yield(string(suffix)) {
+				return
+			}
+
+			// advance like an odometer, stopping after the last suffix
+			i := length - 1
+			for ; i >= 0; i-- {
+				digits[i]++
+				if digits[i] < len(alphabet) {
+					break
+				}
+				digits[i] = 0
+			}
+			if i < 0 {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package yulid
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestEnumerateSuffixesCount(t *testing.T) {
+	for _, alphabet := range []string{alphanumeric, AlphabetHumanSafe, "ba"} {
+		for _, length := range []int{1, 2, 3} {
+			suffixes := slices.Collect(EnumerateSuffixes(length, WithAlphabet(alphabet)))
+			if want := int(math.Pow(float64(len(alphabet)), float64(length))); len(suffixes) != want {
+				t.Fatalf("EnumerateSuffixes(%d) over %q yielded %d suffixes, want %d", length, alphabet, len(suffixes), want)
+			}
+			if !slices.IsSorted(suffixes) {
+				t.Fatalf("EnumerateSuffixes(%d) over %q is not in lexicographic order", length, alphabet)
+			}
+			if len(slices.Compact(suffixes)) != len(suffixes) {
+				t.Fatalf("EnumerateSuffixes(%d) over %q repeats a suffix", length, alphabet)
+			}
+			for _, s := range suffixes {
+				if strings.Trim(s, alphabet) != "" {
+					t.Fatalf("EnumerateSuffixes(%d) over %q yielded %q", length, alphabet, s)
+				}
+			}
+		}
+	}
+
+	if got := slices.Collect(EnumerateSuffixes(1)); got[0] != "0" || got[10] != "A" || got[35] != "Z" {
+		t.Fatalf("EnumerateSuffixes(1) = %q, want 0-9 then A-Z", got)
+	}
+}
+
+func TestEnumerateSuffixesUsesConfigure(t *testing.T) {
+	defer Configure()
+	if err := Configure(WithAlphabet("XY")); err != nil {
+		t.Fatal(err)
+	}
+	if got := slices.Collect(EnumerateSuffixes(2)); !slices.Equal(got, []string{"XX", "XY", "YX", "YY"}) {
+		t.Fatalf("EnumerateSuffixes(2) under Configure = %q", got)
+	}
+}
+
+func TestEnumerateSuffixesOutOfRange(t *testing.T) {
+	for _, length := range []int{-1, 0, maxSuffixLen + 1} {
+		for s := range EnumerateSuffixes(length) {
+			t.Fatalf("EnumerateSuffixes(%d) yielded %q", length, s)
+		}
+	}
+	for s := range EnumerateSuffixes(2, WithAlphabet("A")) {
+		t.Fatalf("EnumerateSuffixes with an invalid alphabet yielded %q", s)
+	}
+}
+
+func TestEnumerateSuffixesOrdinalRoundTrip(t *testing.T) {
+	seen := make(map[int64]bool)
+	i := 0
+	for s := range EnumerateSuffixes(minSuffixLen) {
+		n, err := MustParse("JNDE-" + s).SuffixOrdinal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seen[n.Int64()] {
+			t.Fatalf("suffix %q repeats ordinal %v", s, n)
+		}
+		seen[n.Int64()] = true
+		if back, err := SuffixFromOrdinal(n, minSuffixLen); err != nil || back != s {
+			t.Fatalf("SuffixFromOrdinal(%v) = %q, %v; want %q", n, back, err, s)
+		}
+		if i++; i == 5000 {
+			break
+		}
+	}
+}
+
+func TestSeqStopsOnInvalidPrefix(t *testing.T) {
+	n := 0
+	for id, err := range Seq("jn!e") {
+		if err == nil || !id.IsZero() {
+			t.Fatalf("Seq yielded %q, %v for an invalid prefix", id, err)
+		}
+		n++
+	}
+	if n != 1 {
+		t.Fatalf("Seq yielded %d times for an invalid prefix, want 1", n)
+	}
+}
+
+func TestSeqYieldsUnique(t *testing.T) {
+	seen := make(Set)
+	for id, err := range Seq("JNDE") {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seen.Contains(id) {
+			t.Fatalf("Seq repeated %q", id)
+		}
+		if seen.Add(id); len(seen) == 100 {
+			break
+		}
+	}
+}
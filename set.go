@@ -0,0 +1,61 @@
+package yulid
+
+import "slices"
+
+// Set is a collection of unique YULIDs.
+//
+// YULID is a comparable array, so it can be used directly as a map key. Suffixes
+// shorter than the maximum leave trailing zero bytes in the array; New and Parse
+// always zero-fill the unused tail, so two YULIDs with the same string form are
+// always equal as keys and membership checks are reliable.
+type Set map[YULID]struct{}
+
+// NewSet returns a Set containing ids
+func NewSet(ids ...YULID) Set {
+	s := make(Set, len(ids))
+	for _, id := range ids {
+		s.Add(id)
+	}
+	return s
+}
+
+// Add inserts id into the set
+func (s Set) Add(id YULID) {
+	s[id] = struct{}{}
+}
+
+// Contains reports whether id is in the set
+func (s Set) Contains(id YULID) bool {
+	_, ok := s[id]
+	return ok
+}
+
+// Remove deletes id from the set
+func (s Set) Remove(id YULID) {
+	delete(s, id)
+}
+
+// Slice returns the members of the set in no particular order
+func (s Set) Slice() []YULID {
+	ids := make([]YULID, 0, len(s))
+	for id := range s {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// DistinctPrefixes returns the sorted, unique prefixes present in ids
+func DistinctPrefixes(ids []YULID) []string {
+	seen := make(map[string]struct{})
+	prefixes := []string{}
+	for _, id := range ids {
+		p := id.Prefix()
+		if _, ok := seen[p]; ok {
+			continue
+		}
+		seen[p] = struct{}{}
+		prefixes = append(prefixes, p)
+	}
+	slices.Sort(prefixes)
+	return prefixes
+}
@@ -0,0 +1,60 @@
+package yulid
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSetDedupsParsedAndGenerated(t *testing.T) {
+	g, err := NewGenerator(WithSuffixLength(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	generated, err := g.New("JNDE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := g.Parse(generated.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	unpadded, err := g.ParseBytes([]byte(generated.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewSet(generated, parsed, unpadded)
+	if len(s) != 1 {
+		t.Fatalf("NewSet of one ID in three forms has %d members", len(s))
+	}
+	if !s.Contains(parsed) {
+		t.Fatalf("Contains(%q) = false", parsed)
+	}
+
+	other := MustParse("JNDE-ED24HS")
+	s.Add(other)
+	if got := s.Slice(); len(got) != 2 || !slices.Contains(got, other) {
+		t.Fatalf("Slice = %q, want two members including %q", got, other)
+	}
+	s.Remove(generated)
+	if s.Contains(parsed) || len(s) != 1 {
+		t.Fatalf("Remove(%q) left %q", generated, s.Slice())
+	}
+}
+
+func TestDistinctPrefixes(t *testing.T) {
+	ids := []YULID{
+		MustParse("ZQXW-AB12"),
+		MustParse("JNDE-AB12"),
+		MustParse("ACME-AB12"),
+		MustParse("JNDE-CD34"),
+		MustParse("ZQXW-EF56"),
+	}
+	want := []string{"ACME", "JNDE", "ZQXW"}
+	if got := DistinctPrefixes(ids); !slices.Equal(got, want) {
+		t.Fatalf("DistinctPrefixes = %q, want %q", got, want)
+	}
+	if got := DistinctPrefixes(nil); got == nil || len(got) != 0 {
+		t.Fatalf("DistinctPrefixes(nil) = %#v, want an empty slice", got)
+	}
+}
@@ -0,0 +1,151 @@
+package yulid
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	sortableTimeLen = 4         // sortableTimeLen is the number of suffix characters holding the timestamp
+	sortableUnit    = time.Hour // sortableUnit is the resolution of the embedded timestamp
+
+	// timeDigits are the base-36 digits of the embedded timestamp, in ASCII
+	// order so that the string form of sortable IDs sorts by time
+	timeDigits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+)
+
+// sortableEpoch is the zero point of the embedded timestamp. Four base-36
+// digits of hours cover roughly 190 years from it.
+var sortableEpoch = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// sortableGenerator validates the standard format with a 6-character suffix,
+// the layout of every timestamped YULID
+var sortableGenerator = &Generator{opts: func() options {
+	o := defaultOptions()
+	o.suffixLen = maxSuffixLen
+	return o
+}()}
+
+// timeNow is the clock used by NewSortable
+var timeNow = time.Now
+
+// NewSortable generates a YULID whose suffix starts with the creation time, so
+// that IDs sharing a prefix sort roughly by creation time when compared as
+// strings. The first four suffix characters encode the hours since 2024-01-01
+// UTC in base 36 and the last two are random.
+//
+// With only two random characters there are 1296 distinct IDs per prefix per
+// hour, so sortable IDs suit low-volume prefixes or a UniquenessChecker-backed
+// retry at the call site.
+//
+// Sortable IDs always use the standard format, with the '-' separator and
+// the uppercase alphanumeric alphabet, regardless of Configure, so that
+// Timestamp can decode them wherever they end up.
+func NewSortable(prefix string) (YULID, error) {
+	return newStamped(prefix, timeNow())
+}
+
+// NewSortableAt is like NewSortable but embeds t instead of the current time,
+// for backfilling historical records and for tests that need a fixed clock.
+// t must fall within the roughly 190 years after 2024-01-01 UTC that the
+// timestamp covers.
+func NewSortableAt(prefix string, t time.Time) (YULID, error) {
+	return newStamped(prefix, t)
+}
+
+// NewTimedBatch generates n distinct YULIDs for prefix that all carry t in
+// the NewSortable layout, as when importing a batch of historical events
+// sharing one time. Only the two random characters tell them apart, so n may
+// be at most 1296; larger batches fail with ErrKeyspaceExhausted.
+func NewTimedBatch(prefix string, t time.Time, n int) ([]YULID, error) {
+	if n < 0 {
+		return nil, ErrNegativeBatchSize
+	}
+	if n > timedTails {
+		return nil, ErrKeyspaceExhausted
+	}
+
+	batch := make([]YULID, 0, n)
+	var taken [timedTails]bool
+	for len(batch) < n {
+		id, err := newStamped(prefix, t)
+		if err != nil {
+			return nil, err
+		}
+
+		// step from the random tail to the next free one, so a nearly full
+		// batch still completes in one pass
+		tail := id[prefixLen+separatorLen+sortableTimeLen:]
+		k := strings.IndexByte(timeDigits, tail[0])*len(timeDigits) + strings.IndexByte(timeDigits, tail[1])
+		for taken[k] {
+			k = (k + 1) % timedTails
+		}
+		taken[k] = true
+		tail[0], tail[1] = timeDigits[k/len(timeDigits)], timeDigits[k%len(timeDigits)]
+		batch = append(batch, id)
+	}
+	return batch, nil
+}
+
+// timedTails is the number of distinct random tails behind a sortable timestamp
+const timedTails = 36 * 36
+
+// newStamped generates a YULID whose suffix is t encoded by encodeTime followed
+// by random characters, in the standard format
+func newStamped(prefix string, t time.Time) (YULID, error) {
+	if err := standardGenerator.checkPrefix(prefix); err != nil {
+		return YULID{}, err
+	}
+
+	stamp, err := encodeTime(t)
+	if err != nil {
+		return YULID{}, err
+	}
+
+	var yd YULID
+	copy(yd[:], prefix)
+	yd[prefixLen] = '-'
+	copy(yd[prefixLen+separatorLen:], stamp)
+	if err := standardGenerator.opts.random(yd[prefixLen+separatorLen+sortableTimeLen:], alphanumeric); err != nil {
+		return YULID{}, err
+	}
+
+	return yd, nil
+}
+
+// Timestamp returns the creation time embedded in a YULID from NewSortable,
+// truncated to the hour and in UTC. The timestamp counts hours from the epoch,
+// so it does not depend on the location of the time it was generated from.
+// It returns false if yd is not a valid YULID with a 6-character suffix. Any
+// such ID decodes to some time, so callers must know the ID came from
+// NewSortable for the result to be meaningful.
+func Timestamp(yd YULID) (time.Time, bool) {
+	if sortableGenerator.Validate(yd) != nil {
+		return time.Time{}, false
+	}
+
+	var units int64
+	for _, c := range yd[prefixLen+separatorLen : prefixLen+separatorLen+sortableTimeLen] {
+		units = units*int64(len(timeDigits)) + int64(strings.IndexByte(timeDigits, c))
+	}
+	return sortableEpoch.Add(time.Duration(units) * sortableUnit), true
+}
+
+// encodeTime returns t as sortableTimeLen base-36 digits of units since the epoch
+func encodeTime(t time.Time) ([]byte, error) {
+	units := int64(t.Sub(sortableEpoch) / sortableUnit)
+	if units < 0 {
+		return nil, errors.New("time is before the sortable YULID epoch")
+	}
+
+	stamp := make([]byte, sortableTimeLen)
+	for i := sortableTimeLen - 1; i >= 0; i-- {
+		stamp[i] = timeDigits[units%int64(len(timeDigits))]
+		units /= int64(len(timeDigits))
+	}
+	if units != 0 {
+		return nil, errors.New("time is beyond the range of sortable YULIDs")
+	}
+	return stamp, nil
+}
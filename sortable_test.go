@@ -0,0 +1,183 @@
+package yulid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewSortableAtFixedClock(t *testing.T) {
+	at := time.Date(2026, time.March, 14, 15, 9, 26, 0, time.UTC)
+	tails := make(map[string]bool)
+	var stamp string
+	for i := 0; i < 20; i++ {
+		id, err := NewSortableAt("JNDE", at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		suffix := id.Suffix()
+		if stamp == "" {
+			stamp = suffix[:sortableTimeLen]
+		} else if suffix[:sortableTimeLen] != stamp {
+			t.Fatalf("%q has timestamp %q, want %q", id, suffix[:sortableTimeLen], stamp)
+		}
+		tails[suffix[sortableTimeLen:]] = true
+
+		got, ok := Timestamp(id)
+		if !ok || !got.Equal(at.Truncate(sortableUnit)) {
+			t.Fatalf("Timestamp(%q) = %v, %v; want %v", id, got, ok, at.Truncate(sortableUnit))
+		}
+	}
+	if len(tails) < 2 {
+		t.Fatal("every ID at a fixed clock had the same random tail")
+	}
+}
+
+func TestNewSortableUsesClock(t *testing.T) {
+	defer func(now func() time.Time) { timeNow = now }(timeNow)
+	at := time.Date(2030, time.July, 1, 8, 30, 0, 0, time.UTC)
+	timeNow = func() time.Time { return at }
+
+	id, err := NewSortable("JNDE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := Timestamp(id); !got.Equal(at.Truncate(time.Hour)) {
+		t.Fatalf("Timestamp(%q) = %v, want %v", id, got, at.Truncate(time.Hour))
+	}
+}
+
+func TestNewSortableAtOutOfRange(t *testing.T) {
+	for _, at := range []time.Time{sortableEpoch.Add(-time.Hour), sortableEpoch.Add(time.Duration(hybridSpan) * time.Hour)} {
+		if id, err := NewSortableAt("JNDE", at); err == nil {
+			t.Errorf("NewSortableAt(%v) = %q", at, id)
+		}
+	}
+}
+
+func TestSortableOrder(t *testing.T) {
+	earlier, err := NewSortableAt("JNDE", time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	later, err := NewSortableAt("JNDE", time.Date(2025, time.January, 1, 1, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if earlier.String() >= later.String() {
+		t.Fatalf("%q does not sort before %q", earlier, later)
+	}
+}
+
+func TestNewTimedBatch(t *testing.T) {
+	at := time.Date(2025, time.June, 30, 23, 59, 0, 0, time.UTC)
+	for _, n := range []int{0, 1, 100, timedTails} {
+		batch, err := NewTimedBatch("JNDE", at, n)
+		if err != nil {
+			t.Fatalf("NewTimedBatch(%d): %v", n, err)
+		}
+		if len(batch) != n {
+			t.Fatalf("NewTimedBatch(%d) returned %d IDs", n, len(batch))
+		}
+		seen := make(Set, n)
+		for _, id := range batch {
+			if seen.Contains(id) {
+				t.Fatalf("NewTimedBatch(%d) repeated %q", n, id)
+			}
+			seen.Add(id)
+			if got, ok := Timestamp(id); !ok || !got.Equal(at.Truncate(sortableUnit)) {
+				t.Fatalf("Timestamp(%q) = %v, want %v", id, got, at.Truncate(sortableUnit))
+			}
+		}
+	}
+	if _, err := NewTimedBatch("JNDE", at, timedTails+1); !errors.Is(err, ErrKeyspaceExhausted) {
+		t.Fatalf("NewTimedBatch over the keyspace: err = %v, want ErrKeyspaceExhausted", err)
+	}
+	if _, err := NewTimedBatch("JNDE", at, -1); err == nil {
+		t.Fatal("NewTimedBatch accepted n = -1")
+	}
+}
+
+func TestTimestampIgnoresLocation(t *testing.T) {
+	instant := time.Date(2026, time.October, 25, 0, 30, 0, 0, time.UTC)
+	zones := []*time.Location{
+		time.UTC,
+		time.FixedZone("UTC+14", 14*60*60),
+		time.FixedZone("UTC-9:30", -(9*60+30)*60),
+		time.FixedZone("UTC+5:45", (5*60+45)*60),
+	}
+	for _, name := range []string{"America/New_York", "Europe/London", "Asia/Kolkata", "Australia/Lord_Howe"} {
+		if loc, err := time.LoadLocation(name); err == nil {
+			zones = append(zones, loc)
+		}
+	}
+
+	var stamp string
+	for _, loc := range zones {
+		local := instant.In(loc)
+		id, err := NewSortableAt("JNDE", local)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := id.Suffix()[:sortableTimeLen]; stamp == "" {
+			stamp = s
+		} else if s != stamp {
+			t.Errorf("%v encodes as %q, want %q", local, s, stamp)
+		}
+
+		got, ok := Timestamp(id)
+		if !ok || !got.Equal(instant.Truncate(sortableUnit)) || got.Location() != time.UTC {
+			t.Errorf("Timestamp of %v = %v, want %v in UTC", local, got, instant.Truncate(sortableUnit))
+		}
+	}
+
+	h := Hybrid{Unit: time.Minute, TimeLen: 4, Clock: func() time.Time { return instant.In(zones[1]) }}
+	g, err := NewGenerator(WithSuffixStrategy(h))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, ok := h.Timestamp(mustNew(t, g, "JNDE"), alphanumeric)
+	if !ok || !got.Equal(instant.Truncate(time.Minute)) || got.Location() != time.UTC {
+		t.Errorf("Hybrid timestamp = %v, want %v in UTC", got, instant.Truncate(time.Minute))
+	}
+}
+
+func TestSortableIgnoresConfigure(t *testing.T) {
+	defer Configure()
+	if err := Configure(WithSeparator('.'), WithAlphabet(AlphabetHumanSafe)); err != nil {
+		t.Fatal(err)
+	}
+
+	at := time.Date(2026, time.March, 14, 15, 0, 0, 0, time.UTC)
+	sortable, err := NewSortableAt("JNDE", at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	batch, err := NewTimedBatch("JNDE", at, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	monotonic, err := (&MonotonicTimedGenerator{Clock: func() time.Time { return at }}).New("JNDE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	temporary, err := NewTemporary("JNDE", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range append(batch, sortable, monotonic) {
+		if err := standardGenerator.Validate(id); err != nil {
+			t.Errorf("%q is not in the standard format: %v", id, err)
+		}
+		if got, ok := Timestamp(id); !ok || !got.Equal(at) {
+			t.Errorf("Timestamp(%q) = %v, %v; want %v", id, got, ok, at)
+		}
+	}
+	if _, ok := MonotonicCounter(monotonic); !ok {
+		t.Errorf("MonotonicCounter(%q) failed under Configure", monotonic)
+	}
+	if err := ValidateAt(temporary, timeNow()); err != nil {
+		t.Errorf("ValidateAt(%q) under Configure: %v", temporary, err)
+	}
+}
@@ -0,0 +1,104 @@
+package yulid
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+var (
+	_ sql.Scanner   = (*YULID)(nil)
+	_ driver.Valuer = YULID{}
+	_ sql.Scanner   = (*NullYULID)(nil)
+	_ driver.Valuer = NullYULID{}
+)
+
+// scanUppercase is set by SetScanUppercase
+var scanUppercase atomic.Bool
+
+// SetScanUppercase controls whether Scan uppercases ASCII letters in column
+// values before validating them, for data migrated from systems that stored
+// IDs in lowercase. The default is strict: lowercase values are rejected, as
+// by Parse. It applies to every Scan call, including NullYULID's, so set it
+// once at startup.
+func SetScanUppercase(on bool) {
+	scanUppercase.Store(on)
+}
+
+// Scan implements sql.Scanner, accepting string and []byte column values that
+// hold a valid YULID. NULL is an error; use NullYULID for nullable columns.
+// Lowercase values are rejected unless SetScanUppercase is on.
+func (yd *YULID) Scan(src any) error {
+	var (
+		id  YULID
+		err error
+	)
+	switch v := src.(type) {
+	case string:
+		if scanUppercase.Load() {
+			v = strings.ToUpper(v)
+		}
+		id, err = Parse(v)
+	case []byte:
+		if scanUppercase.Load() {
+			v = bytes.ToUpper(v)
+		}
+		id, err = ParseBytes(v)
+	case nil:
+		return errors.New("cannot scan NULL into YULID")
+	default:
+		return fmt.Errorf("cannot scan %T into YULID", src)
+	}
+	if err != nil {
+		return err
+	}
+
+	*yd = id
+	return nil
+}
+
+// Value implements driver.Valuer, storing yd as its canonical string form.
+// Like Scan it accepts only valid YULIDs in the default format, so anything
+// written can be read back. The zero YULID is an error rather than an empty
+// string; use NullYULID to store NULL.
+func (yd YULID) Value() (driver.Value, error) {
+	if yd.IsZero() {
+		return nil, errors.New("cannot store the zero YULID; use NullYULID for NULL")
+	}
+	if err := Validate(yd); err != nil {
+		return nil, err
+	}
+	return yd.String(), nil
+}
+
+// NullYULID is a YULID that may be NULL, analogous to sql.NullString
+type NullYULID struct {
+	YULID YULID
+	Valid bool // Valid is true if YULID is not NULL
+}
+
+// Scan implements sql.Scanner
+func (n *NullYULID) Scan(src any) error {
+	if src == nil {
+		n.YULID, n.Valid = YULID{}, false
+		return nil
+	}
+	if err := n.YULID.Scan(src); err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements driver.Valuer
+func (n NullYULID) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.YULID.Value()
+}
@@ -0,0 +1,74 @@
+package yulid
+
+import "testing"
+
+func TestValueScanRoundTrip(t *testing.T) {
+	id := MustParse("JNDE-ED24HS")
+	v, err := id.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got YULID
+	if err := got.Scan(v); err != nil {
+		t.Fatalf("Scan(%q): %v", v, err)
+	}
+	if got != id {
+		t.Fatalf("round trip of %q gave %q", id, got)
+	}
+	if err := got.Scan([]byte("JNDE-AB12")); err != nil || got != MustParse("JNDE-AB12") {
+		t.Fatalf("Scan([]byte) = %q, %v", got, err)
+	}
+}
+
+func TestValueRejectsUnreadable(t *testing.T) {
+	for _, id := range []YULID{{}, {'J', 'N', 'D', 'E', '_', 'A', 'B', 'C', 'D'}} {
+		if v, err := id.Value(); err == nil {
+			t.Errorf("Value(%q) = %q, want an error", id, v)
+		}
+	}
+}
+
+func TestScanRejects(t *testing.T) {
+	var id YULID
+	for _, src := range []any{nil, "", "JNDE-ED24H!", 42} {
+		if err := id.Scan(src); err == nil {
+			t.Errorf("Scan(%#v) succeeded", src)
+		}
+	}
+}
+
+func TestNullYULID(t *testing.T) {
+	var n NullYULID
+	if v, err := n.Value(); v != nil || err != nil {
+		t.Fatalf("invalid NullYULID Value = %v, %v; want nil, nil", v, err)
+	}
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Fatalf("Scan(nil) = %v, Valid %v", err, n.Valid)
+	}
+	if err := n.Scan("JNDE-ED24HS"); err != nil || !n.Valid || n.YULID != MustParse("JNDE-ED24HS") {
+		t.Fatalf("Scan = %v, %+v", err, n)
+	}
+	if err := n.Scan("bogus"); err == nil || n.Valid {
+		t.Fatalf("Scan(bogus) = %v, Valid %v", err, n.Valid)
+	}
+}
+
+func TestScanUppercase(t *testing.T) {
+	want := MustParse("JNDE-ED24HS")
+	var id YULID
+	if err := id.Scan([]byte("jnde-ed24hs")); err == nil {
+		t.Fatal("strict Scan accepted lowercase bytes")
+	}
+
+	SetScanUppercase(true)
+	defer SetScanUppercase(false)
+	for _, src := range []any{[]byte("jnde-ed24hs"), "jnde-Ed24hs"} {
+		if err := id.Scan(src); err != nil || id != want {
+			t.Fatalf("Scan(%q) = %q, %v; want %q", src, id, err, want)
+		}
+	}
+	var n NullYULID
+	if err := n.Scan([]byte("jnde-ed24hs")); err != nil || !n.Valid || n.YULID != want {
+		t.Fatalf("NullYULID.Scan = %+v, %v", n, err)
+	}
+}
@@ -0,0 +1,193 @@
+package yulid
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SuffixStrategy produces the characters of generated suffixes. NextSuffix
+// fills dst, whose length is the suffix length less any check character, with
+// characters from alphabet for an ID under prefix. It must be safe for
+// concurrent use.
+//
+// Candidates still pass through the SuffixFilter and UniquenessChecker, and a
+// rejected one is replaced by calling NextSuffix again.
+type SuffixStrategy interface {
+	NextSuffix(prefix, alphabet string, dst []byte) error
+}
+
+// WithSuffixStrategy makes generation take suffixes from s instead of
+// crypto/rand. WithEntropy and WithRetryPolicy only apply to the default
+// strategy.
+func WithSuffixStrategy(s SuffixStrategy) Option {
+	return func(o *options) {
+		o.strategy = s
+	}
+}
+
+// CryptoRandom is the default strategy: every character is drawn uniformly
+// from crypto/rand
+type CryptoRandom struct{}
+
+// NextSuffix implements SuffixStrategy
+func (CryptoRandom) NextSuffix(_, alphabet string, dst []byte) error {
+	return generateRandom(dst, nil, alphabet)
+}
+
+// Counter issues sequential suffixes per prefix, such as "JNDE-0001",
+// "JNDE-0002", for deployments that favour short readable IDs over
+// unguessable ones. Values start at 1 and are written as fixed-width numbers
+// in the alphabet sorted into ASCII order, so with the default alphabet the
+// digits come first and suffixes sort in issue order. Once a prefix's values
+// no longer fit, generation fails with ErrExhausted.
+//
+// Counts live in memory. To survive restarts, set Persist to record each value
+// durably before it is issued, and call Resume at startup with the last
+// recorded value. The zero value is ready to use.
+type Counter struct {
+	// Persist, if set, is called with each value before it is issued. If it
+	// fails, generation fails and the value is not issued, though it is still
+	// consumed.
+	Persist func(prefix string, value uint64) error
+
+	mu   sync.Mutex
+	last map[string]uint64
+}
+
+// Resume continues prefix's sequence after last, normally the value most
+// recently passed to Persist. It never moves a sequence backwards.
+func (c *Counter) Resume(prefix string, last uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.last == nil {
+		c.last = make(map[string]uint64)
+	}
+	c.last[prefix] = max(c.last[prefix], last)
+}
+
+// NextSuffix implements SuffixStrategy
+func (c *Counter) NextSuffix(prefix, alphabet string, dst []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.last == nil {
+		c.last = make(map[string]uint64)
+	}
+	value := c.last[prefix] + 1
+	if !encodeFixed(dst, value, asciiOrder(alphabet)) {
+		return ErrExhausted
+	}
+	c.last[prefix] = value
+
+	// persist while holding the lock, so values are recorded in issue order
+	if c.Persist != nil {
+		if err := c.Persist(prefix, value); err != nil {
+			return fmt.Errorf("persisting suffix counter: %w", err)
+		}
+	}
+	return nil
+}
+
+// Hybrid suffixes start with the time of generation and end with random
+// characters, so IDs under a prefix sort roughly by creation time while
+// staying hard to guess. The time is written in the alphabet sorted into
+// ASCII order as units since 2024-01-01 UTC.
+//
+// The zero value covers the same span as NewSortable: with the alphanumeric
+// alphabet that is four characters of hours, leaving two random characters
+// in a 6-character suffix. Smaller alphabets use as many characters as they
+// need to cover that span, and generators reject a Hybrid that
+// leaves no room for random characters.
+type Hybrid struct {
+	TimeLen int              // characters of timestamp; 0 sizes it from the alphabet
+	Unit    time.Duration    // timestamp resolution; 0 means an hour
+	Clock   func() time.Time // source of the current time; nil means time.Now
+}
+
+// hybridSpan is the number of time units the zero Hybrid must be able to
+// encode, the range of a NewSortable timestamp
+var hybridSpan = uint64(math.Pow(float64(len(alphanumeric)), sortableTimeLen))
+
+// timeLen returns the number of timestamp characters h writes with alphabet
+func (h Hybrid) timeLen(alphabet string) int {
+	if h.TimeLen != 0 {
+		return h.TimeLen
+	}
+	n := 1
+	for capacity := uint64(len(alphabet)); capacity < hybridSpan; capacity *= uint64(len(alphabet)) {
+		n++
+	}
+	return n
+}
+
+// unit returns the timestamp resolution of h
+func (h Hybrid) unit() time.Duration {
+	if h.Unit == 0 {
+		return sortableUnit
+	}
+	return h.Unit
+}
+
+// NextSuffix implements SuffixStrategy
+func (h Hybrid) NextSuffix(_, alphabet string, dst []byte) error {
+	timeLen, unit := h.timeLen(alphabet), h.unit()
+	if timeLen >= len(dst) {
+		return fmt.Errorf("%w: hybrid timestamp of %d characters leaves no room for random ones", ErrInvalidOption, timeLen)
+	}
+
+	now := timeNow
+	if h.Clock != nil {
+		now = h.Clock
+	}
+	units := now().Sub(sortableEpoch) / unit
+	if units < 0 || !encodeFixed(dst[:timeLen], uint64(units), asciiOrder(alphabet)) {
+		return fmt.Errorf("time is outside the range of %d-character hybrid timestamps", timeLen)
+	}
+	return generateRandom(dst[timeLen:], nil, alphabet)
+}
+
+// Timestamp returns the time embedded in the suffix of id by h, truncated to
+// h's unit and in UTC, where alphabet is the alphabet id was generated with.
+// It returns false if the suffix is too short to hold the timestamp or holds
+// characters outside alphabet.
+func (h Hybrid) Timestamp(id YULID, alphabet string) (time.Time, bool) {
+	suffix := id.SuffixBytes()
+	timeLen := h.timeLen(alphabet)
+	if timeLen >= len(suffix) {
+		return time.Time{}, false
+	}
+
+	digits := asciiOrder(alphabet)
+	var units uint64
+	for _, c := range suffix[:timeLen] {
+		d := strings.IndexByte(digits, c)
+		if d < 0 {
+			return time.Time{}, false
+		}
+		units = units*uint64(len(digits)) + uint64(d)
+	}
+	return sortableEpoch.Add(time.Duration(units) * h.unit()), true
+}
+
+// encodeFixed writes n into dst as a fixed-width number with digits, most
+// significant first. It reports false if n does not fit.
+func encodeFixed(dst []byte, n uint64, digits string) bool {
+	base := uint64(len(digits))
+	for i := len(dst) - 1; i >= 0; i-- {
+		dst[i] = digits[n%base]
+		n /= base
+	}
+	return n == 0
+}
+
+// asciiOrder returns alphabet sorted into ASCII order
+func asciiOrder(alphabet string) string {
+	b := []byte(alphabet)
+	slices.Sort(b)
+	return string(b)
+}
@@ -0,0 +1,123 @@
+package yulid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCounter(t *testing.T) {
+	var persisted []uint64
+	c := &Counter{Persist: func(prefix string, value uint64) error {
+		persisted = append(persisted, value)
+		return nil
+	}}
+	g, err := NewGenerator(WithSuffixStrategy(c))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"JNDE-000001", "JNDE-000002"} {
+		id, err := g.New("JNDE")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id.String() != want {
+			t.Fatalf("New = %q, want %q", id, want)
+		}
+	}
+	c.Resume("JNDE", 35)
+	c.Resume("JNDE", 1) // never moves backwards
+	if id, _ := g.New("JNDE"); id.String() != "JNDE-000010" {
+		t.Fatalf("New after Resume(35) = %q, want JNDE-000010", id)
+	}
+	if id, _ := g.New("ACME"); id.String() != "ACME-000001" {
+		t.Fatalf("New for another prefix = %q, want ACME-000001", id)
+	}
+	if len(persisted) != 4 || persisted[2] != 36 {
+		t.Fatalf("persisted %v, want 4 values with 36 third", persisted)
+	}
+}
+
+func TestCounterExhausts(t *testing.T) {
+	g, err := NewGenerator(WithAlphabet("AB"), WithSuffixLength(4), WithSuffixStrategy(&Counter{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i < 16; i++ {
+		if _, err := g.New("JNDE"); err != nil {
+			t.Fatalf("value %d: %v", i, err)
+		}
+	}
+	if _, err := g.New("JNDE"); !errors.Is(err, ErrExhausted) {
+		t.Fatalf("err = %v, want ErrExhausted once 4 characters no longer fit", err)
+	}
+}
+
+func TestCounterPersistFailure(t *testing.T) {
+	failed := errors.New("disk full")
+	g, err := NewGenerator(WithSuffixStrategy(&Counter{
+		Persist: func(string, uint64) error { return failed },
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.New("JNDE"); !errors.Is(err, failed) {
+		t.Fatalf("err = %v, want the Persist error", err)
+	}
+}
+
+func TestHybridSizesTimestampFromAlphabet(t *testing.T) {
+	tests := []struct {
+		alphabet string
+		want     int
+	}{
+		{alphanumeric, 4},
+		{AlphabetLetters, 5},
+		{AlphabetDigits, 7},
+	}
+	for _, tt := range tests {
+		if got := (Hybrid{}).timeLen(tt.alphabet); got != tt.want {
+			t.Errorf("timeLen(%q) = %d, want %d", tt.alphabet, got, tt.want)
+		}
+	}
+}
+
+func TestHybridRejectsAlphabetWithoutRoom(t *testing.T) {
+	_, err := NewGenerator(WithAlphabet(AlphabetDigits), WithSuffixStrategy(Hybrid{}))
+	if !errors.Is(err, ErrInvalidOption) {
+		t.Fatalf("NewGenerator with digits and zero Hybrid: err = %v, want ErrInvalidOption", err)
+	}
+}
+
+func TestHybridCoversSortableSpan(t *testing.T) {
+	defer func(now func() time.Time) { timeNow = now }(timeNow)
+	// the last hour a NewSortable timestamp can hold
+	timeNow = func() time.Time { return sortableEpoch.Add(time.Duration(hybridSpan-1) * time.Hour) }
+
+	g, err := NewGenerator(WithAlphabet(AlphabetLetters), WithSuffixStrategy(Hybrid{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.New("JNDE"); err != nil {
+		t.Fatalf("New at the end of the sortable span: %v", err)
+	}
+}
+
+func TestHybridClock(t *testing.T) {
+	at := time.Date(2026, time.May, 2, 13, 45, 12, 0, time.UTC)
+	h := Hybrid{Unit: time.Minute, TimeLen: 4, Clock: func() time.Time { return at }}
+	g, err := NewGenerator(WithSuffixStrategy(h))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, b := mustNew(t, g, "JNDE"), mustNew(t, g, "JNDE")
+	if a.Suffix()[:4] != b.Suffix()[:4] {
+		t.Fatalf("%q and %q have different timestamps at a fixed clock", a, b)
+	}
+	for _, id := range []YULID{a, b} {
+		got, ok := h.Timestamp(id, alphanumeric)
+		if !ok || !got.Equal(at.Truncate(time.Minute)) {
+			t.Fatalf("Timestamp(%q) = %v, %v; want %v", id, got, ok, at.Truncate(time.Minute))
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package yulid
+
+import "context"
+
+// Stream keeps up to buffer freshly generated YULIDs for prefix ready in a
+// channel, refilled by a background goroutine, so callers that cannot afford a
+// slow UniquenessChecker round trip can take pre-generated IDs:
+//
+//	ids, errc := g.Stream(ctx, "JNDE", 64)
+//	...
+//	id, ok := <-ids
+//	if !ok {
+//		return <-errc
+//	}
+//
+// Generation stops and both channels are closed when ctx is cancelled or
+// generation fails. A failure is sent on the error channel before it is
+// closed; cancellation sends nothing, so a receive on it then yields nil. IDs
+// still buffered when generation stops can be drained. Every ID is checked
+// against the UniquenessChecker when generated rather than when received, so
+// a long-buffered ID may have been taken in the meantime by another process.
+func (g *Generator) Stream(ctx context.Context, prefix string, buffer int) (<-chan YULID, <-chan error) {
+	ids := make(chan YULID, max(buffer, 0))
+	errc := make(chan error, 1)
+
+	go func() {
+		defer close(errc)
+		defer close(ids)
+		for {
+			id, err := g.NewContext(ctx, prefix)
+			if err != nil {
+				if ctx.Err() == nil {
+					errc <- err
+				}
+				return
+			}
+			select {
+			case ids <- id:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ids, errc
+}
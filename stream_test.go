@@ -0,0 +1,116 @@
+package yulid
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestStream(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ids, errc := standardGenerator.Stream(ctx, "JNDE", 8)
+	seen := make(Set)
+	for i := 0; i < 100; i++ {
+		id, ok := <-ids
+		if !ok {
+			t.Fatalf("stream closed after %d IDs: %v", i, <-errc)
+		}
+		if err := Validate(id); err != nil || id.Prefix() != "JNDE" {
+			t.Fatalf("streamed %q: %v", id, err)
+		}
+		if seen.Contains(id) {
+			t.Fatalf("streamed %q twice", id)
+		}
+		seen.Add(id)
+	}
+}
+
+func TestStreamCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ids, errc := standardGenerator.Stream(ctx, "JNDE", 4)
+	if _, ok := <-ids; !ok {
+		t.Fatal("stream closed before cancellation")
+	}
+	cancel()
+
+	// the buffered IDs drain, then the channel closes; a send can still win
+	// the race with cancellation now and then, but never for long
+	drained := 0
+	for range ids {
+		if drained++; drained > 100 {
+			t.Fatal("stream kept producing after cancellation")
+		}
+	}
+	if err := <-errc; err != nil {
+		t.Fatalf("error after cancellation = %v, want nil", err)
+	}
+}
+
+// failingChecker fails once it has been asked about n IDs
+type failingChecker struct{ n int }
+
+var errCheckerDown = errors.New("checker unavailable")
+
+func (c *failingChecker) Exists(context.Context, YULID) (bool, error) {
+	if c.n == 0 {
+		return false, errCheckerDown
+	}
+	c.n--
+	return false, nil
+}
+
+func TestStreamError(t *testing.T) {
+	ids, errc := standardGenerator.Stream(context.Background(), "jn", 4)
+	if id, ok := <-ids; ok {
+		t.Fatalf("stream for an invalid prefix produced %q", id)
+	}
+	if err := <-errc; !errors.Is(err, ErrorInvalidInput) {
+		t.Fatalf("error = %v, want ErrorInvalidInput", err)
+	}
+
+	g, err := NewGenerator(WithUniquenessChecker(&failingChecker{n: 3}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids, errc = g.Stream(context.Background(), "JNDE", 0)
+	got := 0
+	for range ids {
+		got++
+	}
+	if got != 3 {
+		t.Errorf("stream produced %d IDs before the checker failed, want 3", got)
+	}
+	if err := <-errc; !errors.Is(err, errCheckerDown) {
+		t.Fatalf("error = %v, want the checker's", err)
+	}
+	if _, ok := <-errc; ok {
+		t.Fatal("error channel not closed after the error")
+	}
+}
+
+func TestStreamNoLeak(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	var cancels []context.CancelFunc
+	for i := 0; i < 20; i++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancels = append(cancels, cancel)
+		// never read, so the goroutines block on full or unbuffered channels
+		standardGenerator.Stream(ctx, "JNDE", i%3)
+	}
+	for _, cancel := range cancels {
+		cancel()
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d goroutines still running after cancellation, %d before streaming", runtime.NumGoroutine(), before)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
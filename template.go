@@ -0,0 +1,58 @@
+package yulid
+
+import (
+	"fmt"
+	"text/template"
+)
+
+// FuncMap returns template helpers for rendering YULIDs. Each helper accepts
+// either a YULID or its string form:
+//
+//   - yulid: the canonical string form
+//   - yulidRedacted: the prefix with all but the last two suffix characters masked, e.g. "JNDE-****HS"
+//   - yulidPrefix: the 4-character prefix
+//
+// Register it before parsing templates:
+//
+//	tmpl := template.New("page").Funcs(FuncMap())
+//
+// The returned map is also accepted by html/template's Funcs.
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"yulid": func(v any) (string, error) {
+			yd, err := templateArg(v)
+			if err != nil {
+				return "", err
+			}
+			return yd.String(), nil
+		},
+		"yulidRedacted": func(v any) (string, error) {
+			yd, err := templateArg(v)
+			if err != nil {
+				return "", err
+			}
+			return yd.Masked(), nil
+		},
+		"yulidPrefix": func(v any) (string, error) {
+			yd, err := templateArg(v)
+			if err != nil {
+				return "", err
+			}
+			return yd.Prefix(), nil
+		},
+	}
+}
+
+// templateArg converts a template argument into a validated YULID
+func templateArg(v any) (YULID, error) {
+	switch t := v.(type) {
+	case YULID:
+		return t, Validate(t)
+	case *YULID:
+		return *t, Validate(*t)
+	case string:
+		return Parse(t)
+	default:
+		return YULID{}, fmt.Errorf("yulid: unsupported template argument of type %T", v)
+	}
+}
@@ -0,0 +1,57 @@
+package yulid
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestFuncMap(t *testing.T) {
+	id := MustParse("JNDE-ED24HS")
+	tests := []struct {
+		text string
+		data any
+		want string
+	}{
+		{`{{yulid .}}`, id, "JNDE-ED24HS"},
+		{`{{yulid .}}`, &id, "JNDE-ED24HS"},
+		{`{{yulid .}}`, "JNDE-ED24HS", "JNDE-ED24HS"},
+		{`{{yulidRedacted .}}`, id, "JNDE-****HS"},
+		{`{{yulidRedacted .}}`, "JNDE-ED24HS", "JNDE-****HS"},
+		{`{{yulidPrefix .}}`, id, "JNDE"},
+		{`{{yulidPrefix .}}`, "JNDE-ED24HS", "JNDE"},
+	}
+	for _, tt := range tests {
+		tmpl := template.Must(template.New("t").Funcs(FuncMap()).Parse(tt.text))
+		var b strings.Builder
+		if err := tmpl.Execute(&b, tt.data); err != nil {
+			t.Errorf("%s with %T: %v", tt.text, tt.data, err)
+			continue
+		}
+		if b.String() != tt.want {
+			t.Errorf("%s with %T = %q, want %q", tt.text, tt.data, b.String(), tt.want)
+		}
+	}
+}
+
+func TestFuncMapRejectsInvalid(t *testing.T) {
+	tmpl := template.Must(template.New("t").Funcs(FuncMap()).Parse(`{{yulid .}}`))
+	for _, data := range []any{"JNDE_ED24HS", YULID{}, 42} {
+		if err := tmpl.Execute(&strings.Builder{}, data); err == nil {
+			t.Errorf("yulid accepted %#v", data)
+		}
+	}
+}
+
+func TestFuncMapHTMLTemplate(t *testing.T) {
+	tmpl := htmltemplate.Must(htmltemplate.New("t").Funcs(htmltemplate.FuncMap(FuncMap())).
+		Parse(`<span title="{{yulidPrefix .}}">{{yulidRedacted .}}</span>`))
+	var b strings.Builder
+	if err := tmpl.Execute(&b, "JNDE-ED24HS"); err != nil {
+		t.Fatal(err)
+	}
+	if want := `<span title="JNDE">JNDE-****HS</span>`; b.String() != want {
+		t.Fatalf("rendered %q, want %q", b.String(), want)
+	}
+}
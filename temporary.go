@@ -0,0 +1,53 @@
+package yulid
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrExpired is returned by ValidateAt for a temporary YULID past its expiry
+var ErrExpired = errors.New("YULID has expired")
+
+// NewTemporary generates a YULID that stops validating under ValidateAt once
+// ttl has elapsed. The expiry is embedded in the suffix with the same layout
+// as NewSortable, four base-36 digits of hours followed by two random
+// characters, and is rounded up to the next whole hour so an ID never expires
+// early. The same 1296-IDs-per-prefix-per-hour limit applies.
+func NewTemporary(prefix string, ttl time.Duration) (YULID, error) {
+	if ttl <= 0 {
+		return YULID{}, errors.New("temporary YULID lifetime must be positive")
+	}
+
+	expiry := timeNow().Add(ttl)
+	if t := expiry.Truncate(sortableUnit); t.Before(expiry) {
+		expiry = t.Add(sortableUnit)
+	}
+	return newStamped(prefix, expiry)
+}
+
+// ExpiresAt returns the expiry embedded in a YULID from NewTemporary. It
+// returns false if yd is not a valid YULID with a 6-character suffix. As with
+// Timestamp, any such ID decodes to some time, so callers must know the ID
+// came from NewTemporary.
+func ExpiresAt(yd YULID) (time.Time, bool) {
+	return Timestamp(yd)
+}
+
+// ValidateAt validates a YULID from NewTemporary as of t, returning
+// ErrExpired if t is at or after its expiry. It must not be used for
+// permanent IDs, whose suffix would be read as an arbitrary expiry. Like
+// NewSortable, temporary IDs are in the standard format regardless of
+// Configure.
+func ValidateAt(id YULID, t time.Time) error {
+	if err := standardGenerator.Validate(id); err != nil {
+		return err
+	}
+	expiry, ok := ExpiresAt(id)
+	if !ok {
+		return ErrInvalidLength
+	}
+	if !t.Before(expiry) {
+		return ErrExpired
+	}
+	return nil
+}
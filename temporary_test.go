@@ -0,0 +1,96 @@
+package yulid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewTemporaryExpiry(t *testing.T) {
+	defer func(now func() time.Time) { timeNow = now }(timeNow)
+	now := time.Date(2026, time.March, 14, 15, 9, 26, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+
+	tests := []struct {
+		ttl  time.Duration
+		want time.Time
+	}{
+		// expiries round up to the next whole hour, never down
+		{time.Second, time.Date(2026, time.March, 14, 16, 0, 0, 0, time.UTC)},
+		{50*time.Minute + 34*time.Second, time.Date(2026, time.March, 14, 16, 0, 0, 0, time.UTC)},
+		{50*time.Minute + 35*time.Second, time.Date(2026, time.March, 14, 17, 0, 0, 0, time.UTC)},
+		{24 * time.Hour, time.Date(2026, time.March, 15, 16, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		id, err := NewTemporary("JNDE", tt.ttl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, ok := ExpiresAt(id); !ok || !got.Equal(tt.want) {
+			t.Errorf("ExpiresAt(NewTemporary(%v)) = %v, %v; want %v", tt.ttl, got, ok, tt.want)
+		}
+	}
+
+	// a ttl ending exactly on the hour is not rounded further
+	timeNow = func() time.Time { return time.Date(2026, time.March, 14, 15, 0, 0, 0, time.UTC) }
+	id, err := NewTemporary("JNDE", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := ExpiresAt(id); !got.Equal(time.Date(2026, time.March, 14, 16, 0, 0, 0, time.UTC)) {
+		t.Errorf("ExpiresAt of a ttl ending on the hour = %v", got)
+	}
+}
+
+func TestValidateAtBoundary(t *testing.T) {
+	defer func(now func() time.Time) { timeNow = now }(timeNow)
+	timeNow = func() time.Time { return time.Date(2026, time.March, 14, 15, 9, 26, 0, time.UTC) }
+
+	id, err := NewTemporary("JNDE", 30*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expiry := time.Date(2026, time.March, 14, 16, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want error
+	}{
+		{"at issue", timeNow(), nil},
+		{"just before expiry", expiry.Add(-time.Nanosecond), nil},
+		{"exactly at expiry", expiry, ErrExpired},
+		{"just after expiry", expiry.Add(time.Nanosecond), ErrExpired},
+		{"long after expiry", expiry.Add(365 * 24 * time.Hour), ErrExpired},
+		{"at expiry in another zone", expiry.In(time.FixedZone("UTC+5", 5*3600)), ErrExpired},
+	}
+	for _, tt := range tests {
+		if err := ValidateAt(id, tt.at); !errors.Is(err, tt.want) {
+			t.Errorf("%s: ValidateAt(%q, %v) = %v, want %v", tt.name, id, tt.at, err, tt.want)
+		}
+	}
+}
+
+func TestValidateAtMalformed(t *testing.T) {
+	at := time.Date(2026, time.March, 14, 15, 0, 0, 0, time.UTC)
+	if err := ValidateAt(MustParse("JNDE-AB12"), at); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("ValidateAt of a 4-character suffix: err = %v, want ErrInvalidLength", err)
+	}
+	if err := ValidateAt(YULID{'J', 'N', 'D', 'E', '_', 'A', 'B', '1', '2', 'C', 'D'}, at); !errors.Is(err, ErrInvalidSeparator) {
+		t.Errorf("ValidateAt of a bad separator: err = %v, want ErrInvalidSeparator", err)
+	}
+	if _, ok := ExpiresAt(MustParse("JNDE-AB12C")); ok {
+		t.Error("ExpiresAt accepted a 5-character suffix")
+	}
+}
+
+func TestNewTemporaryRejectsLifetime(t *testing.T) {
+	for _, ttl := range []time.Duration{0, -time.Hour} {
+		if id, err := NewTemporary("JNDE", ttl); err == nil {
+			t.Errorf("NewTemporary(%v) = %q", ttl, id)
+		}
+	}
+	if _, err := NewTemporary("jn", time.Hour); !errors.Is(err, ErrorInvalidInput) {
+		t.Errorf("NewTemporary with a bad prefix: err = %v, want ErrorInvalidInput", err)
+	}
+}
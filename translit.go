@@ -0,0 +1,83 @@
+package yulid
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Transliterator converts a name in any script into an ASCII approximation for
+// prefix derivation. Characters it cannot convert may be dropped.
+type Transliterator interface {
+	Transliterate(name string) string
+}
+
+// TransliteratorFunc adapts a function to the Transliterator interface
+type TransliteratorFunc func(name string) string
+
+// Transliterate implements Transliterator
+func (f TransliteratorFunc) Transliterate(name string) string {
+	return f(name)
+}
+
+// WithTransliterator sets how names are converted to ASCII by NewFromName and
+// PrefixFromName. The default is LatinFolding.
+func WithTransliterator(t Transliterator) Option {
+	return func(o *options) {
+		o.transliterator = t
+	}
+}
+
+// LatinFolding is the default Transliterator. It uppercases the name, folds
+// accented Latin letters and ligatures to their base ASCII letters ("Ł" to "L",
+// "ß" to "SS", "Æ" to "AE"), romanizes Cyrillic, and drops any other non-ASCII
+// character.
+type LatinFolding struct{}
+
+// Transliterate implements Transliterator
+func (LatinFolding) Transliterate(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(name) {
+		switch {
+		case r < utf8.RuneSelf:
+			b.WriteRune(r)
+		case latinFolds[r] != "":
+			b.WriteString(latinFolds[r])
+		case unicode.IsSpace(r):
+			b.WriteByte(' ')
+		}
+	}
+	return b.String()
+}
+
+// latinFolds maps uppercase non-ASCII letters to ASCII
+var latinFolds = map[rune]string{
+	// Latin-1 Supplement
+	'À': "A", 'Á': "A", 'Â': "A", 'Ã': "A", 'Ä': "A", 'Å': "A", 'Æ': "AE",
+	'Ç': "C", 'È': "E", 'É': "E", 'Ê': "E", 'Ë': "E", 'Ì': "I", 'Í': "I",
+	'Î': "I", 'Ï': "I", 'Ð': "D", 'Ñ': "N", 'Ò': "O", 'Ó': "O", 'Ô': "O",
+	'Õ': "O", 'Ö': "O", 'Ø': "O", 'Ù': "U", 'Ú': "U", 'Û': "U", 'Ü': "U",
+	'Ý': "Y", 'Þ': "TH", 'ß': "SS", 'Ÿ': "Y",
+
+	// Latin Extended-A
+	'Ā': "A", 'Ă': "A", 'Ą': "A", 'Ć': "C", 'Ĉ': "C", 'Ċ': "C", 'Č': "C",
+	'Ď': "D", 'Đ': "D", 'Ē': "E", 'Ĕ': "E", 'Ė': "E", 'Ę': "E", 'Ě': "E",
+	'Ĝ': "G", 'Ğ': "G", 'Ġ': "G", 'Ģ': "G", 'Ĥ': "H", 'Ħ': "H", 'Ĩ': "I",
+	'Ī': "I", 'Ĭ': "I", 'Į': "I", 'İ': "I", 'Ĳ': "IJ", 'Ĵ': "J", 'Ķ': "K",
+	'Ĺ': "L", 'Ļ': "L", 'Ľ': "L", 'Ŀ': "L", 'Ł': "L", 'Ń': "N", 'Ņ': "N",
+	'Ň': "N", 'Ŋ': "NG", 'Ō': "O", 'Ŏ': "O", 'Ő': "O", 'Œ': "OE", 'Ŕ': "R",
+	'Ŗ': "R", 'Ř': "R", 'Ś': "S", 'Ŝ': "S", 'Ş': "S", 'Š': "S", 'Ţ': "T",
+	'Ť': "T", 'Ŧ': "T", 'Ũ': "U", 'Ū': "U", 'Ŭ': "U", 'Ů': "U", 'Ű': "U",
+	'Ų': "U", 'Ŵ': "W", 'Ŷ': "Y", 'Ź': "Z", 'Ż': "Z", 'Ž': "Z",
+
+	// Latin Extended-B, common in Romanian and Vietnamese
+	'Ș': "S", 'Ț': "T", 'Ơ': "O", 'Ư': "U",
+
+	// Cyrillic
+	'А': "A", 'Б': "B", 'В': "V", 'Г': "G", 'Д': "D", 'Е': "E", 'Ё': "E",
+	'Ж': "ZH", 'З': "Z", 'И': "I", 'Й': "Y", 'К': "K", 'Л': "L", 'М': "M",
+	'Н': "N", 'О': "O", 'П': "P", 'Р': "R", 'С': "S", 'Т': "T", 'У': "U",
+	'Ф': "F", 'Х': "KH", 'Ц': "TS", 'Ч': "CH", 'Ш': "SH", 'Щ': "SHCH",
+	'Ы': "Y", 'Э': "E", 'Ю': "YU", 'Я': "YA", 'І': "I", 'Ї': "YI", 'Є': "YE",
+	'Ґ': "G",
+}
@@ -0,0 +1,65 @@
+package yulid
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestLatinFolding(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"John Doe", "JOHN DOE"},
+		{"josé garcía", "JOSE GARCIA"},
+		{"Łukasz Żółty", "LUKASZ ZOLTY"},
+		{"Straße", "STRASSE"},
+		{"Æsa Øre", "AESA ORE"},
+		{"Þór", "THOR"},
+		{"Ĳsselmeer", "IJSSELMEER"},
+		{"Œuvre", "OEUVRE"},
+		{"Nguyễn Ơn", "NGUYN ON"}, // ễ carries two marks and is not folded
+		{"Ștefan Țiriac", "STEFAN TIRIAC"},
+		{"Дмитрий Щукин", "DMITRIY SHCHUKIN"},
+		{"Юлия Ёжикова", "YULIYA EZHIKOVA"},
+		{"Ґанна Їжак", "GANNA YIZHAK"},
+		{"Объект", "OBEKT"}, // the hard sign has no romanization
+		{"O'Brien-Smith", "O'BRIEN-SMITH"},
+		{"Ann\u00a0Lee", "ANN LEE"}, // non-ASCII spaces become spaces
+	}
+	for _, tt := range tests {
+		if got := (LatinFolding{}).Transliterate(tt.in); got != tt.want {
+			t.Errorf("Transliterate(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestLatinFoldingDropsOtherScripts(t *testing.T) {
+	for _, in := range []string{"山田太郎", "محمد", "Ελένη", "김민준", "😀"} {
+		if got := (LatinFolding{}).Transliterate(in); strings.TrimSpace(got) != "" {
+			t.Errorf("Transliterate(%q) = %q, want nothing", in, got)
+		}
+	}
+	if got := (LatinFolding{}).Transliterate("Ana 山田"); got != "ANA " {
+		t.Errorf("Transliterate of mixed scripts = %q, want the Latin part", got)
+	}
+}
+
+func TestLatinFoldsTable(t *testing.T) {
+	for r, fold := range latinFolds {
+		if fold == "" {
+			t.Errorf("%q folds to nothing", r)
+		}
+		for i := 0; i < len(fold); i++ {
+			if fold[i] < 'A' || fold[i] > 'Z' {
+				t.Errorf("%q folds to %q, which is not uppercase ASCII", r, fold)
+			}
+		}
+		if r < utf8.RuneSelf {
+			t.Errorf("%q is ASCII and never looked up", r)
+		}
+		if upper := []rune(strings.ToUpper(string(r))); len(upper) != 1 || upper[0] != r {
+			t.Errorf("%q is not uppercase, so Transliterate never looks it up", r)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package yulid
+
+import (
+	"context"
+	"sync"
+)
+
+// UniquenessChecker reports whether a YULID has already been issued. A
+// Generator configured WithUniquenessChecker consults it for every candidate
+// and regenerates on a hit.
+//
+// Implementations are typically backed by the store that records issued IDs:
+//
+//   - Redis: Exists runs EXISTS on a key derived from id.String(), or SISMEMBER
+//     on a per-prefix set; the issuing code adds the key once the ID is used.
+//   - SQL: Exists runs SELECT EXISTS(SELECT 1 FROM customers WHERE yulid = $1),
+//     passing id, which implements driver.Valuer.
+//
+// Checking and recording are separate steps, so two concurrent generators can
+// both see a candidate as free. Keep a unique constraint in the backing store
+// as the final guard and retry on violation.
+type UniquenessChecker interface {
+	Exists(ctx context.Context, id YULID) (bool, error)
+}
+
+// MemoryStore is an in-memory UniquenessChecker, useful in tests and for
+// single-process deployments. IDs must be recorded with Add once issued. It is
+// safe for concurrent use.
+type MemoryStore struct {
+	mu  sync.RWMutex
+	ids Set
+}
+
+// NewMemoryStore returns a MemoryStore holding ids
+func NewMemoryStore(ids ...YULID) *MemoryStore {
+	return &MemoryStore{ids: NewSet(ids...)}
+}
+
+// Exists implements UniquenessChecker
+func (m *MemoryStore) Exists(_ context.Context, id YULID) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.ids.Contains(id), nil
+}
+
+// Add records id as issued
+func (m *MemoryStore) Add(id YULID) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ids.Add(id)
+}
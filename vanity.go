@@ -0,0 +1,66 @@
+package yulid
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrSuffixRejected = errors.New("requested suffix is rejected by the suffix filter")
+	ErrSuffixTaken    = errors.New("requested suffix is already taken")
+)
+
+// NewWithSuffix issues a YULID with a caller-chosen suffix, for vanity IDs such
+// as "JNDE-GOLD1". It is NewWithSuffixContext with a background context.
+func (g *Generator) NewWithSuffix(prefix, suffix string) (YULID, error) {
+	return g.NewWithSuffixContext(context.Background(), prefix, suffix)
+}
+
+// NewWithSuffixContext issues a YULID with a caller-chosen suffix. The result
+// must satisfy the Generator's format, and the suffix must pass its
+// SuffixFilter and, if a UniquenessChecker is configured, be unused; otherwise
+// ErrSuffixRejected or ErrSuffixTaken is returned. With WithChecksum the
+// requested suffix is the data part and the check character is appended.
+func (g *Generator) NewWithSuffixContext(ctx context.Context, prefix, suffix string) (YULID, error) {
+	if g.opts.observer == nil {
+		return g.newWithSuffix(ctx, prefix, suffix)
+	}
+	start := time.Now()
+	id, err := g.newWithSuffix(ctx, prefix, suffix)
+	g.observe(prefix, start, 1, err)
+	return id, err
+}
+
+func (g *Generator) newWithSuffix(ctx context.Context, prefix, suffix string) (YULID, error) {
+	if err := g.checkPrefix(prefix); err != nil {
+		return YULID{}, err
+	}
+	if len(suffix) > maxSuffixLen-(g.opts.generatedSuffixLen()-g.opts.randomLen()) {
+		return YULID{}, ErrInvalidLength
+	}
+
+	var id YULID
+	copy(id[:prefixLen], prefix)
+	id[prefixLen] = g.opts.separator
+	g.opts.writeSuffix(&id, []byte(suffix))
+	if err := g.Validate(id); err != nil {
+		return YULID{}, err
+	}
+
+	if g.opts.filter != nil && g.opts.filter.Reject(id.Prefix(), id.Suffix()) {
+		return YULID{}, ErrSuffixRejected
+	}
+	if g.opts.checker != nil {
+		exists, err := g.opts.checker.Exists(ctx, id)
+		if err != nil {
+			return YULID{}, err
+		}
+		if exists {
+			return YULID{}, ErrSuffixTaken
+		}
+	}
+
+	g.generated.Add(1)
+	return id, nil
+}
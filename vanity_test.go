@@ -0,0 +1,111 @@
+package yulid
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestNewWithSuffix(t *testing.T) {
+	for _, suffix := range []string{"GOLD", "GOLD1", "GOLD12", "0000"} {
+		id, err := standardGenerator.NewWithSuffix("JNDE", suffix)
+		if err != nil {
+			t.Errorf("NewWithSuffix(%q): %v", suffix, err)
+			continue
+		}
+		if id.String() != "JNDE-"+suffix {
+			t.Errorf("NewWithSuffix(%q) = %q", suffix, id)
+		}
+	}
+
+	g, err := NewGenerator(WithSeparator('.'), WithAlphabet(AlphabetHumanSafe))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id, err := g.NewWithSuffix("JNDE", "G01D"); err != nil || id.String() != "JNDE.G01D" {
+		t.Errorf("NewWithSuffix under a custom format = %q, %v", id, err)
+	}
+}
+
+func TestNewWithSuffixRejectsCharacters(t *testing.T) {
+	for _, suffix := range []string{"gold", "GO-LD", "GOLD!", "GÖLD"} {
+		if id, err := standardGenerator.NewWithSuffix("JNDE", suffix); err == nil {
+			t.Errorf("NewWithSuffix(%q) = %q", suffix, id)
+		}
+	}
+
+	var ve *ValidationError
+	if _, err := standardGenerator.NewWithSuffix("JNDE", "GOLd"); !errors.As(err, &ve) || !errors.Is(err, ErrInvalidSuffix) || ve.Index != 8 {
+		t.Errorf("NewWithSuffix(GOLd): err = %v, want ErrInvalidSuffix at 8", err)
+	}
+
+	// I, L, O and U are outside the human-safe alphabet
+	g, err := NewGenerator(WithAlphabet(AlphabetHumanSafe))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.NewWithSuffix("JNDE", "GOLD"); !errors.Is(err, ErrInvalidSuffix) {
+		t.Errorf("NewWithSuffix(GOLD) over the human-safe alphabet: err = %v, want ErrInvalidSuffix", err)
+	}
+	if _, err := standardGenerator.NewWithSuffix("jnde", "GOLD"); !errors.Is(err, ErrorInvalidInput) {
+		t.Errorf("NewWithSuffix with a bad prefix: err = %v, want ErrorInvalidInput", err)
+	}
+}
+
+func TestNewWithSuffixRejectsLength(t *testing.T) {
+	for _, suffix := range []string{"", "GOL", "GOLD123"} {
+		if id, err := standardGenerator.NewWithSuffix("JNDE", suffix); !errors.Is(err, ErrInvalidLength) {
+			t.Errorf("NewWithSuffix(%q) = %q, %v; want ErrInvalidLength", suffix, id, err)
+		}
+	}
+
+	g, err := NewGenerator(WithSuffixLength(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.NewWithSuffix("JNDE", "GOLD1"); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("NewWithSuffix of 5 characters under a 4-character format: err = %v, want ErrInvalidLength", err)
+	}
+}
+
+func TestNewWithSuffixChecksum(t *testing.T) {
+	g, err := NewGenerator(WithChecksum())
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := g.NewWithSuffix("JNDE", "GOLD1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := id.String(); len(s) != maxLen || s[:10] != "JNDE-GOLD1" {
+		t.Fatalf("NewWithSuffix(GOLD1) with a checksum = %q, want the check character appended", id)
+	}
+	if err := g.Validate(id); err != nil {
+		t.Fatalf("Validate(%q): %v", id, err)
+	}
+	if _, err := g.NewWithSuffix("JNDE", "GOLD12"); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("NewWithSuffix leaving no room for the check character: err = %v, want ErrInvalidLength", err)
+	}
+}
+
+func TestNewWithSuffixFilterAndChecker(t *testing.T) {
+	store := NewMemoryStore()
+	store.Add(MustParse("JNDE-TAKEN"))
+	g, err := NewGenerator(
+		WithSuffixFilter(SuffixFilterFunc(func(prefix, suffix string) bool { return suffix == "BADWRD" })),
+		WithUniquenessChecker(store),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := g.NewWithSuffix("JNDE", "BADWRD"); !errors.Is(err, ErrSuffixRejected) {
+		t.Errorf("NewWithSuffix of a filtered suffix: err = %v, want ErrSuffixRejected", err)
+	}
+	if _, err := g.NewWithSuffix("JNDE", "TAKEN"); !errors.Is(err, ErrSuffixTaken) {
+		t.Errorf("NewWithSuffix of a taken suffix: err = %v, want ErrSuffixTaken", err)
+	}
+	if _, err := g.NewWithSuffixContext(context.Background(), "ACME", "TAKEN"); err != nil {
+		t.Errorf("NewWithSuffix of a suffix taken only under another prefix: %v", err)
+	}
+}
@@ -0,0 +1,142 @@
+package yulid
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+)
+
+var (
+	ErrUnknownVersion = errors.New("unknown YULID format version")
+)
+
+// StandardVersion is the format version of the standard format, registered
+// by default: a 4-6 character A-Z and 0-9 suffix after a '-', generated at 6
+// characters.
+const StandardVersion = 1
+
+// formatVersion is a registered format
+type formatVersion struct {
+	opts []Option
+	gen  *Generator
+}
+
+var (
+	versionsMu sync.RWMutex
+	versions   = map[int]formatVersion{
+		StandardVersion: {gen: standardGenerator},
+	}
+)
+
+// RegisterFormatVersion records the format described by opts under version,
+// so that IDs issued in it stay parseable after the default moves on.
+// Versions are permanent: registering one that already exists is an error, as
+// is a version below 1 or an invalid format.
+//
+// A typical evolution registers each new format at startup,
+//
+//	yulid.RegisterFormatVersion(2, yulid.WithChecksum())
+//
+// pins new generation to it with WithFormatVersion(2), and accepts IDs of
+// every version with ParseVersioned.
+func RegisterFormatVersion(version int, opts ...Option) error {
+	if version < 1 {
+		return fmt.Errorf("%w: format version %d must be at least 1", ErrInvalidOption, version)
+	}
+	g, err := NewGenerator(opts...)
+	if err != nil {
+		return err
+	}
+
+	versionsMu.Lock()
+	defer versionsMu.Unlock()
+
+	if _, ok := versions[version]; ok {
+		return fmt.Errorf("%w: format version %d is already registered", ErrInvalidOption, version)
+	}
+	versions[version] = formatVersion{opts: slices.Clone(opts), gen: g}
+	return nil
+}
+
+// WithFormatVersion applies the options of a registered format version, and
+// pins a Generator to it so that Generator.Version reports it. Options after
+// it override the version's own. An unregistered version makes the options
+// invalid with ErrUnknownVersion.
+func WithFormatVersion(version int) Option {
+	return func(o *options) {
+		versionsMu.RLock()
+		v, ok := versions[version]
+		versionsMu.RUnlock()
+
+		if !ok {
+			o.versionErr = fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+			return
+		}
+		for _, opt := range v.opts {
+			opt(o)
+		}
+		o.version = version
+	}
+}
+
+// Version returns the format version the Generator is pinned to with
+// WithFormatVersion, or 0 if it is not pinned to one.
+func (g *Generator) Version() int {
+	return g.opts.version
+}
+
+// FormatVersions returns the registered format versions in ascending order
+func FormatVersions() []int {
+	versionsMu.RLock()
+	defer versionsMu.RUnlock()
+	return slices.Sorted(maps.Keys(versions))
+}
+
+// ParseVersioned parses s under each registered format version, newest first,
+// and returns the YULID with the first version that accepts it. If none does,
+// the error is that of the newest version.
+//
+// Formats can overlap: a standard ID has a 1 in 36 chance of also carrying a
+// valid check character, for example. The reported version is therefore the
+// newest one s is valid under, not necessarily the one that issued it.
+func ParseVersioned(s string) (YULID, int, error) {
+	var id YULID
+	version, err := dispatch(func(g *Generator) error {
+		var err error
+		id, err = g.Parse(s)
+		return err
+	})
+	if err != nil {
+		return YULID{}, 0, err
+	}
+	return id, version, nil
+}
+
+// ValidateVersioned is like ParseVersioned for a YULID value, returning the
+// version it validates under
+func ValidateVersioned(id YULID) (int, error) {
+	return dispatch(func(g *Generator) error {
+		return g.Validate(id)
+	})
+}
+
+// dispatch runs check against each registered format, newest first, and
+// returns the first version it succeeds for
+func dispatch(check func(*Generator) error) (int, error) {
+	versionsMu.RLock()
+	defer versionsMu.RUnlock()
+
+	var first error
+	for _, v := range slices.Backward(slices.Sorted(maps.Keys(versions))) {
+		err := check(versions[v].gen)
+		if err == nil {
+			return v, nil
+		}
+		if first == nil {
+			first = err
+		}
+	}
+	return 0, first
+}
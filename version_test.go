@@ -0,0 +1,46 @@
+package yulid
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestFormatVersions(t *testing.T) {
+	const version = 92
+	if !slices.Contains(FormatVersions(), version) {
+		if err := RegisterFormatVersion(version, WithSeparator('_')); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := RegisterFormatVersion(version); !errors.Is(err, ErrInvalidOption) {
+		t.Fatalf("re-registering version %d: err = %v, want ErrInvalidOption", version, err)
+	}
+	if err := RegisterFormatVersion(0); !errors.Is(err, ErrInvalidOption) {
+		t.Fatalf("registering version 0: err = %v, want ErrInvalidOption", err)
+	}
+	if _, err := NewGenerator(WithFormatVersion(999)); !errors.Is(err, ErrUnknownVersion) {
+		t.Fatalf("WithFormatVersion(999): err = %v, want ErrUnknownVersion", err)
+	}
+
+	g, err := NewGenerator(WithFormatVersion(version))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Version() != version {
+		t.Fatalf("Version() = %d, want %d", g.Version(), version)
+	}
+	id, err := g.New("JNDE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, v, err := ParseVersioned(id.String()); err != nil || v != version {
+		t.Fatalf("ParseVersioned(%q) = %d, %v, want version %d", id, v, err, version)
+	}
+	if v, err := ValidateVersioned(id); err != nil || v != version {
+		t.Fatalf("ValidateVersioned(%q) = %d, %v, want version %d", id, v, err, version)
+	}
+	if _, _, err := ParseVersioned("JNDE!AB12CD"); err == nil {
+		t.Fatal("ParseVersioned accepted an ID no version issues")
+	}
+}
@@ -0,0 +1,18 @@
+package yulid
+
+// MarshalYAML implements the Marshaler interface of gopkg.in/yaml.v2 and v3,
+// encoding yd as its canonical string form. It is defined without importing a
+// YAML package; v3 would also use MarshalText, but v2 would not.
+func (yd YULID) MarshalYAML() (any, error) {
+	return yd.String(), nil
+}
+
+// UnmarshalYAML implements the Unmarshaler interface of gopkg.in/yaml.v2,
+// which v3 also honours. The value must be a string holding a valid YULID.
+func (yd *YULID) UnmarshalYAML(unmarshal func(any) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	return yd.UnmarshalText([]byte(s))
+}
@@ -0,0 +1,24 @@
+package yulid
+
+import "testing"
+
+func TestYAMLRoundTrip(t *testing.T) {
+	id := MustParse("JNDE-ED24HS")
+	v, err := id.MarshalYAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// unmarshal stands in for the decoder, storing the scalar v was encoded as
+	unmarshal := func(out any) error {
+		*out.(*string) = v.(string)
+		return nil
+	}
+	var got YULID
+	if err := got.UnmarshalYAML(unmarshal); err != nil {
+		t.Fatal(err)
+	}
+	if got != id {
+		t.Fatalf("round trip of %q gave %q", id, got)
+	}
+}
@@ -0,0 +1,444 @@
+package yulid
+
+import (
+	"bufio"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+var (
+	ErrorInvalidInput = errors.New("input should be exactly four alphanumeric characters")
+
+	// specific prefix errors; each wraps ErrorInvalidInput
+	ErrEmptyPrefix       = fmt.Errorf("%w: prefix is empty", ErrorInvalidInput)
+	ErrPrefixTooShort    = fmt.Errorf("%w: prefix is too short", ErrorInvalidInput)
+	ErrPrefixTooLong     = fmt.Errorf("%w: prefix is too long", ErrorInvalidInput)
+	ErrPrefixInvalidChar = fmt.Errorf("%w: prefix contains a non-alphanumeric character", ErrorInvalidInput)
+
+	// errors returned by Validate and Parse, identifying the invalid component
+	ErrInvalidLength    = errors.New("YULID has an invalid length")
+	ErrInvalidPrefix    = errors.New("YULID has an invalid prefix")
+	ErrInvalidSeparator = errors.New("YULID separator is invalid")
+	ErrInvalidSuffix    = errors.New("YULID random part contains invalid characters")
+	ErrNonASCII         = errors.New("YULID contains non-ASCII bytes")
+
+	ErrEntropy = errors.New("failed to read from the entropy source")
+)
+
+const (
+	prefixLen    = 4 // prefixLen represents the length of the prefix in a YULID
+	separatorLen = 1 // separator is denoted by a hyphen '-' in a YULID
+	minSuffixLen = 4 // minSuffixLen represents the minimum length of the random part in a YULID after the separator
+	maxSuffixLen = 6 // maxSuffixLen represents the maximum length of the random part in a YULID after the separator
+	alphanumeric = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+	minLen = prefixLen + separatorLen + minSuffixLen // minLen is the shortest valid YULID, inclusive
+	maxLen = prefixLen + separatorLen + maxSuffixLen // maxLen is the longest valid YULID, inclusive
+)
+
+// YULID represents a distinct, human-readable identifier for a Yul customer.
+// This identifier is designed to be shared easily and combines a user-specific prefix with a random, alphanumeric suffix.
+//
+// The format of a YULID is: [4-character prefix] + '-' + [4-6 character random string].
+//
+// Example:
+// For a user with the full name "John Doe", their YULID might be "JNDE-ED24HS".
+// - "JNDE" is the prefix derived from the user's name.
+// - "ED24HS" is the random alphanumeric suffix generated for uniqueness.
+type YULID [maxLen]byte
+
+// String implements the Stringer interface for YULID
+func (yd YULID) String() string {
+	for i, b := range yd {
+		if b == 0 {
+			return string(yd[:i])
+		}
+	}
+	return string(yd[:])
+}
+
+// IsZero reports whether yd is the zero value, as left by an uninitialized
+// variable or a failed constructor
+func (yd YULID) IsZero() bool {
+	return yd == YULID{}
+}
+
+// Equal reports whether yd and other have the same string form. Bytes after
+// the first zero byte are padding and are ignored, so arrays that differ only
+// in stray bytes past the end of the ID are equal. Values from New and Parse
+// are always zero-padded, so for them Equal agrees with ==.
+func (yd YULID) Equal(other YULID) bool {
+	return yd.String() == other.String()
+}
+
+// Lower returns the canonical form with ASCII letters lowercased. It is a
+// presentation transform only; the stored value is unchanged. Parse rejects
+// the lowercase form, but ParseLenient reads it back as yd.
+func (yd YULID) Lower() string {
+	return strings.ToLower(yd.String())
+}
+
+// Folded returns a case-folded key for yd, suitable for a separately indexed
+// column backing case-insensitive lookups and unique constraints. Case variants
+// of the same ID fold to the same key; String keeps the original case.
+func (yd YULID) Folded() string {
+	return strings.ToUpper(yd.String())
+}
+
+// Prefix returns the 4-character prefix of the YULID, or less if the array is
+// zero-padded within the prefix, as in the zero value
+func (yd YULID) Prefix() string {
+	for i, b := range yd[:prefixLen] {
+		if b == 0 {
+			return string(yd[:i])
+		}
+	}
+	return string(yd[:prefixLen])
+}
+
+// Suffix returns the part of the YULID after the separator, excluding the zero
+// padding
+func (yd YULID) Suffix() string {
+	return string(yd.SuffixBytes())
+}
+
+// Len returns the length of the YULID's string form, excluding the zero padding
+func (yd YULID) Len() int {
+	return len(yd.String())
+}
+
+// New generates a YULID with the given 4-character alphanumeric prefix and a
+// random suffix, 6 characters from A-Z and 0-9 separated by '-' unless opts
+// say otherwise. It uses the default Generator when no options are given.
+//
+// New is safe for concurrent use. With no options it draws from pooled
+// crypto/rand readers and does not allocate.
+func New(prefix string, opts ...Option) (YULID, error) {
+	g, err := generatorFor(opts)
+	if err != nil {
+		return YULID{}, err
+	}
+	return g.New(prefix)
+}
+
+// MustNew is like New but panics on error. It is intended for tests and
+// package-level variable initialization.
+func MustNew(prefix string, opts ...Option) YULID {
+	yd, err := New(prefix, opts...)
+	if err != nil {
+		panic("yulid: MustNew(" + strconv.Quote(prefix) + "): " + err.Error())
+	}
+	return yd
+}
+
+// NewPadded generates a YULID from a 1-3 character prefix by right-padding it
+// with pad up to the full prefix length. A full-length prefix is used as is.
+func NewPadded(prefix string, pad byte) (YULID, error) {
+	switch {
+	case len(prefix) == 0:
+		return YULID{}, ErrEmptyPrefix
+	case len(prefix) > prefixLen:
+		return YULID{}, ErrPrefixTooLong
+	case !isAlphanumeric(rune(pad)):
+		return YULID{}, ErrPrefixInvalidChar
+	}
+	return New(prefix + strings.Repeat(string(pad), prefixLen-len(prefix)))
+}
+
+// validatePrefix checks that prefix is exactly prefixLen alphanumeric characters
+func validatePrefix(prefix string) error {
+	switch {
+	case len(prefix) == 0:
+		return ErrEmptyPrefix
+	case len(prefix) < prefixLen:
+		return ErrPrefixTooShort
+	case len(prefix) > prefixLen:
+		return ErrPrefixTooLong
+	}
+	// check bytes rather than runes, so a multi-byte character is rejected on
+	// its own bytes instead of relying on how it decodes
+	for i := 0; i < len(prefix); i++ {
+		if !isAlphanumeric(rune(prefix[i])) {
+			return ErrPrefixInvalidChar
+		}
+	}
+	return nil
+}
+
+// NewRandomPrefix generates a YULID with a random alphanumeric prefix, for
+// system-internal identifiers that have no meaningful owner. The prefix is never
+// one of DefaultReservedPrefixes; a reserved draw is redrawn within the retry
+// limit of the default Generator, after which it fails with ErrExhausted.
+func NewRandomPrefix() (YULID, error) {
+	g := defaultGenerator()
+	for i := 0; i <= g.opts.maxRetries(); i++ {
+		var prefix [prefixLen]byte
+		if err := g.opts.random(prefix[:], alphanumeric); err != nil {
+			return YULID{}, err
+		}
+		if !isDefaultReserved(string(prefix[:])) {
+			return g.New(string(prefix[:]))
+		}
+	}
+	return YULID{}, ErrExhausted
+}
+
+// entropySource is a buffered reader over crypto/rand together with scratch
+// space for raw entropy, pooled so that generation does not allocate
+type entropySource struct {
+	r   *bufio.Reader
+	buf [32]byte
+}
+
+// entropyPool holds entropy sources. Each source is used by a single goroutine
+// at a time, so buffered bytes are never handed out twice.
+var entropyPool = sync.Pool{
+	New: func() any {
+		return &entropySource{r: bufio.NewReaderSize(rand.Reader, 64)}
+	},
+}
+
+// generateRandom fills dst with random characters drawn from alphabet, reading
+// from entropy, or from a pooled crypto/rand reader if entropy is nil.
+//
+// Entropy is read in bulk and each byte is mapped onto the alphabet by rejection
+// sampling: bytes at or above the largest multiple of len(alphabet) that fits in
+// a byte are discarded, so every character stays equally likely.
+func generateRandom(dst []byte, entropy io.Reader, alphabet string) error {
+	// borrow a source so parallel callers don't each hit the system source,
+	// and so the scratch buffer need not be allocated per call
+	src := entropyPool.Get().(*entropySource)
+	defer entropyPool.Put(src)
+	if entropy == nil {
+		entropy = src.r
+	}
+
+	limit := 256 - 256%len(alphabet)
+	for i := 0; i < len(dst); {
+		// read twice what is still needed, leaving slack for rejected bytes
+		chunk := src.buf[:min(len(src.buf), 2*(len(dst)-i))]
+		if _, err := io.ReadFull(entropy, chunk); err != nil {
+			return fmt.Errorf("%w: %w", ErrEntropy, err)
+		}
+		for _, b := range chunk {
+			if int(b) >= limit {
+				continue
+			}
+			dst[i] = alphabet[int(b)%len(alphabet)]
+			i++
+			if i == len(dst) {
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+func isAlphanumeric(b rune) bool {
+	return (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9')
+}
+
+// Parse converts the canonical string form of a YULID back into a YULID,
+// validating it. Strings longer than the maximum length are rejected with
+// ErrInvalidLength rather than truncated; other errors identify the invalid
+// component as in Validate. opts describe the expected format, as for New.
+func Parse(s string, opts ...Option) (YULID, error) {
+	g, err := generatorFor(opts)
+	if err != nil {
+		return YULID{}, err
+	}
+	return g.Parse(s)
+}
+
+// MustParse is like Parse but panics on error. It is intended for tests and
+// package-level variable initialization.
+func MustParse(s string, opts ...Option) YULID {
+	yd, err := Parse(s, opts...)
+	if err != nil {
+		panic("yulid: MustParse(" + strconv.Quote(s) + "): " + err.Error())
+	}
+	return yd
+}
+
+// ParseBytes is like Parse but takes a byte slice, copying it straight into the
+// YULID without an intermediate string.
+func ParseBytes(b []byte, opts ...Option) (YULID, error) {
+	g, err := generatorFor(opts)
+	if err != nil {
+		return YULID{}, err
+	}
+	return g.ParseBytes(b)
+}
+
+// ParseLenient is like Parse but accepts input as users type or paste it:
+// zero-width characters and a byte order mark are removed, surrounding
+// whitespace and one pair of matching quotes are trimmed, and ASCII letters
+// are uppercased into the canonical form before parsing, so " jnde-ed24hs "
+// and "\ufeff\"JNDE-ED24HS\"" both parse as "JNDE-ED24HS". Parse still rejects
+// all of these. ParseLenient is not suitable for alphabets containing
+// lowercase letters.
+func ParseLenient(s string, opts ...Option) (YULID, error) {
+	g, err := generatorFor(opts)
+	if err != nil {
+		return YULID{}, err
+	}
+	return g.ParseLenient(s)
+}
+
+func parse[T string | []byte](s T, o options) (YULID, error) {
+	var yd YULID
+	n := len(s)
+	switch {
+	case o.optionalSeparator && n >= prefixLen+minSuffixLen && n < maxLen && s[prefixLen] != o.separator:
+		// insert the missing separator at the prefix boundary
+		copy(yd[:prefixLen], s[:prefixLen])
+		yd[prefixLen] = o.separator
+		copy(yd[prefixLen+separatorLen:], s[prefixLen:])
+		n += separatorLen
+	case n > maxLen:
+		return YULID{}, ErrInvalidLength
+	default:
+		copy(yd[:], s)
+	}
+	if o.normalize {
+		o.normalizeSuffix(&yd)
+	}
+	if err := validate(yd, o); err != nil {
+		return YULID{}, err
+	}
+	if yd.Len() != n {
+		// s had a zero byte inside it, which would otherwise hide trailing data
+		return YULID{}, ErrInvalidLength
+	}
+	return yd, nil
+}
+
+// SuffixBytes returns the suffix of yd, from after the separator up to the first
+// zero byte, without allocating.
+//
+// The returned slice aliases the receiver's array: it must not be modified or
+// retained beyond the lifetime of yd.
+func (yd *YULID) SuffixBytes() []byte {
+	suffix := yd[prefixLen+separatorLen:]
+	for i, b := range suffix {
+		if b == 0 {
+			return suffix[:i]
+		}
+	}
+	return suffix
+}
+
+// Validate checks if a YULID is correctly formatted. opts describe the expected
+// format, as for New; without them any 4-6 character A-Z and 0-9 suffix after a
+// '-' is accepted.
+func Validate(id YULID, opts ...Option) error {
+	g, err := generatorFor(opts)
+	if err != nil {
+		return err
+	}
+	return g.Validate(id)
+}
+
+// ValidateString checks that s is a correctly formatted YULID without first
+// converting it to a YULID, so input longer than the maximum is rejected with
+// ErrInvalidLength instead of being truncated.
+func ValidateString(s string, opts ...Option) error {
+	_, err := Parse(s, opts...)
+	return err
+}
+
+// ValidateBytes is like ValidateString but takes a byte slice
+func ValidateBytes(b []byte, opts ...Option) error {
+	_, err := ParseBytes(b, opts...)
+	return err
+}
+
+func validate(id YULID, o options) error {
+	if o.normalize {
+		o.normalizeSuffix(&id)
+	}
+
+	// Ensure length is correct. The array cannot hold more than maxLen bytes, so
+	// longer candidates are rejected by Parse before they reach here.
+	ydLen := id.Len()
+	if ydLen < minLen || ydLen > maxLen {
+		return ErrInvalidLength
+	}
+	if o.suffixLen != 0 && ydLen != prefixLen+separatorLen+o.suffixLen {
+		return ErrInvalidLength
+	}
+
+	// Reject high bytes explicitly so UTF-8 or binary garbage is reported as such
+	for i := 0; i < ydLen; i++ {
+		if id[i] >= utf8.RuneSelf {
+			return &ValidationError{Err: ErrNonASCII, Index: i, Char: id[i]}
+		}
+	}
+
+	// Check that the prefix is alphanumeric
+	for i := 0; i < prefixLen; i++ {
+		if !isAlphanumeric(rune(id[i])) {
+			return &ValidationError{Err: ErrInvalidPrefix, Index: i, Char: id[i]}
+		}
+	}
+
+	// Check the separator
+	if id[prefixLen] != o.separator {
+		return &ValidationError{Err: ErrInvalidSeparator, Index: prefixLen, Char: id[prefixLen]}
+	}
+
+	// Check that the suffix part is drawn from the alphabet
+	for i := prefixLen + separatorLen; i < ydLen; i++ {
+		if strings.IndexByte(o.alphabet, id[i]) < 0 {
+			return &ValidationError{Err: ErrInvalidSuffix, Index: i, Char: id[i]}
+		}
+	}
+
+	// Check the check character
+	if o.checksum && !validChecksum(o.alphabet, id[prefixLen+separatorLen:ydLen]) {
+		return &ValidationError{Err: ErrInvalidChecksum, Index: ydLen - 1, Char: id[ydLen-1]}
+	}
+
+	// Check the first suffix character against its set
+	if start := prefixLen + separatorLen; o.firstSet != "" && strings.IndexByte(o.firstSet, id[start]) < 0 {
+		return &ValidationError{Err: ErrInvalidSuffix, Index: start, Char: id[start]}
+	}
+
+	// Check for repeated neighbours
+	if o.noRepeats {
+		if i := adjacentRepeat(id[prefixLen+separatorLen : ydLen]); i >= 0 {
+			i += prefixLen + separatorLen
+			return &ValidationError{Err: ErrAdjacentRepeat, Index: i, Char: id[i]}
+		}
+	}
+
+	if o.validator != nil {
+		return o.validator(id)
+	}
+	return nil
+}
+
+// ValidationError reports the byte of a YULID that failed validation. Err is
+// one of the sentinel errors, such as ErrInvalidPrefix, and can be matched with
+// errors.Is; length errors are returned as the bare ErrInvalidLength, since
+// they have no single offending byte.
+type ValidationError struct {
+	Err   error // the sentinel error for the failed check
+	Index int   // zero-based index of the offending byte
+	Char  byte  // the offending byte
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%v: %q at index %d", e.Err, e.Char, e.Index)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,480 @@
+package yulid
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestNewRandomPrefix(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		id, err := NewRandomPrefix()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := Validate(id); err != nil {
+			t.Fatalf("Validate(%q): %v", id, err)
+		}
+		if isDefaultReserved(id.Prefix()) {
+			t.Fatalf("NewRandomPrefix = %q, which has a reserved prefix", id)
+		}
+	}
+}
+
+func TestNewRandomPrefixSkipsReserved(t *testing.T) {
+	defer Configure()
+
+	// the first draw spells ADMN, the second JNDE; each draw reads twice the
+	// characters it needs
+	entropy := []byte{0, 3, 12, 13, 0, 0, 0, 0, 9, 13, 3, 4, 0, 0, 0, 0}
+	entropy = append(entropy, bytes.Repeat([]byte{1}, 12)...)
+	if err := Configure(WithEntropy(bytes.NewReader(entropy))); err != nil {
+		t.Fatal(err)
+	}
+	id, err := NewRandomPrefix()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.String() != "JNDE-BBBBBB" {
+		t.Fatalf("NewRandomPrefix = %q, want the reserved ADMN draw skipped", id)
+	}
+}
+
+func TestWithReservedPrefixes(t *testing.T) {
+	g, err := NewGenerator(WithReservedPrefixes("ADMN", "ROOT"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, prefix := range []string{"ADMN", "ROOT"} {
+		if id, err := g.New(prefix); !errors.Is(err, ErrReservedPrefix) {
+			t.Errorf("New(%q) = %q, %v; want ErrReservedPrefix", prefix, id, err)
+		}
+	}
+	if _, err := g.New("JNDE"); err != nil {
+		t.Errorf("New(JNDE): %v", err)
+	}
+	// reservations are opt-in
+	if _, err := New("ADMN"); err != nil {
+		t.Errorf("New(ADMN) without reservations: %v", err)
+	}
+
+	n := 0
+	for id, err := range Seq("ROOT", WithReservedPrefixes("ROOT")) {
+		if !errors.Is(err, ErrReservedPrefix) || !id.IsZero() {
+			t.Fatalf("Seq yielded %q, %v for a reserved prefix", id, err)
+		}
+		n++
+	}
+	if n != 1 {
+		t.Fatalf("Seq yielded %d times for a reserved prefix, want 1", n)
+	}
+}
+
+func TestSuffixBytes(t *testing.T) {
+	for _, s := range []string{"JNDE-AB12", "JNDE-AB12C", "JNDE-AB12CD"} {
+		id := MustParse(s)
+		if got := id.SuffixBytes(); string(got) != id.Suffix() {
+			t.Errorf("SuffixBytes(%q) = %q, want %q", id, got, id.Suffix())
+		}
+	}
+
+	id := MustParse("JNDE-AB12CD")
+	if b := id.SuffixBytes(); &b[0] != &id[prefixLen+separatorLen] {
+		t.Error("SuffixBytes does not alias the array")
+	}
+	if allocs := testing.AllocsPerRun(100, func() { _ = id.SuffixBytes() }); allocs != 0 {
+		t.Errorf("SuffixBytes allocates %v times per call, want 0", allocs)
+	}
+}
+
+func TestAccessorsIgnorePadding(t *testing.T) {
+	tests := []struct {
+		yd             YULID
+		prefix, suffix string
+		length         int
+	}{
+		{MustParse("JNDE-AB12"), "JNDE", "AB12", 9},
+		{MustParse("JNDE-AB12CD"), "JNDE", "AB12CD", maxLen},
+		{YULID{'J', 'N'}, "JN", "", 2},
+		{YULID{}, "", "", 0},
+	}
+	for _, tt := range tests {
+		if got := tt.yd.Prefix(); got != tt.prefix {
+			t.Errorf("Prefix(%q) = %q, want %q", tt.yd[:], got, tt.prefix)
+		}
+		if got := tt.yd.Suffix(); got != tt.suffix {
+			t.Errorf("Suffix(%q) = %q, want %q", tt.yd[:], got, tt.suffix)
+		}
+		if got := tt.yd.Len(); got != tt.length {
+			t.Errorf("Len(%q) = %d, want %d", tt.yd[:], got, tt.length)
+		}
+	}
+}
+
+func TestNewPadded(t *testing.T) {
+	tests := []struct {
+		prefix string
+		pad    byte
+		want   string
+	}{
+		{"H", 'X', "HXXX"},
+		{"HR", '0', "HR00"},
+		{"ENG", 'Z', "ENGZ"},
+		{"JNDE", 'X', "JNDE"},
+	}
+	for _, tt := range tests {
+		id, err := NewPadded(tt.prefix, tt.pad)
+		if err != nil {
+			t.Fatalf("NewPadded(%q, %q): %v", tt.prefix, tt.pad, err)
+		}
+		if id.Prefix() != tt.want {
+			t.Errorf("NewPadded(%q, %q).Prefix() = %q, want %q", tt.prefix, tt.pad, id.Prefix(), tt.want)
+		}
+		if err := Validate(id); err != nil {
+			t.Errorf("Validate(%q): %v", id, err)
+		}
+	}
+}
+
+func TestNewPaddedRejects(t *testing.T) {
+	tests := []struct {
+		prefix string
+		pad    byte
+		want   error
+	}{
+		{"", 'X', ErrEmptyPrefix},
+		{"JNDEX", 'X', ErrPrefixTooLong},
+		{"ENG", '-', ErrPrefixInvalidChar},
+		{"ENG", 'x', ErrPrefixInvalidChar},
+		{"en", 'X', ErrPrefixInvalidChar},
+	}
+	for _, tt := range tests {
+		if _, err := NewPadded(tt.prefix, tt.pad); !errors.Is(err, tt.want) {
+			t.Errorf("NewPadded(%q, %q): err = %v, want %v", tt.prefix, tt.pad, err, tt.want)
+		}
+	}
+}
+
+func TestParseLenientRoundTripsLower(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		id := MustNew("JNDE")
+		got, err := ParseLenient(id.Lower())
+		if err != nil {
+			t.Fatalf("ParseLenient(%q): %v", id.Lower(), err)
+		}
+		if got != id {
+			t.Fatalf("ParseLenient(%q) = %q, want %q", id.Lower(), got, id)
+		}
+	}
+	if _, err := Parse(MustParse("JNDE-ED24HS").Lower()); err == nil {
+		t.Fatal("strict Parse accepted the lowercase form")
+	}
+}
+
+func TestAllowMissingSeparator(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"JNDE-ED24HS", "JNDE-ED24HS"},
+		{"JNDEED24HS", "JNDE-ED24HS"},
+		{"JNDEAB12", "JNDE-AB12"},
+		{"JNDE-AB12", "JNDE-AB12"},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.in, AllowMissingSeparator())
+		if err != nil || got != MustParse(tt.want) {
+			t.Errorf("Parse(%q, AllowMissingSeparator()) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+	}
+	for _, in := range []string{"JNDEED24HS1", "JNDEED2", "JNDE_ED24H!"} {
+		if got, err := Parse(in, AllowMissingSeparator()); err == nil {
+			t.Errorf("Parse(%q, AllowMissingSeparator()) = %q", in, got)
+		}
+	}
+	if _, err := Parse("JNDEED24HS"); err == nil {
+		t.Error("strict Parse accepted an ID without its separator")
+	}
+}
+
+func TestParseLenientStripsDecorations(t *testing.T) {
+	want := MustParse("JNDE-ED24HS")
+	for _, in := range []string{
+		"JNDE-ED24HS",
+		" jnde-ed24hs\n",
+		"\ufeffJNDE-ED24HS",
+		`"JNDE-ED24HS"`,
+		"'jnde-ed24hs'",
+		"\u201cJNDE-ED24HS\u201d",
+		"\ufeff \"JNDE-ED24HS\" ",
+		"\u200bJNDE-ED24HS\u200b",
+		"JNDE-\u200dED24HS",
+	} {
+		got, err := ParseLenient(in)
+		if err != nil || got != want {
+			t.Errorf("ParseLenient(%q) = %q, %v; want %q", in, got, err, want)
+		}
+		if in != want.String() {
+			if _, err := Parse(in); err == nil {
+				t.Errorf("strict Parse accepted %q", in)
+			}
+		}
+	}
+	for _, in := range []string{`"JNDE-ED24HS'`, `""JNDE-ED24HS""`, `"`} {
+		if got, err := ParseLenient(in); err == nil {
+			t.Errorf("ParseLenient(%q) = %q", in, got)
+		}
+	}
+}
+
+func TestLengthBoundaries(t *testing.T) {
+	tests := []struct {
+		s    string
+		want error
+	}{
+		{"JNDE-AB1", ErrInvalidLength},      // 8
+		{"JNDE-AB12", nil},                  // 9, the minimum
+		{"JNDE-AB12C", nil},                 // 10
+		{"JNDE-AB12CD", nil},                // 11, the maximum
+		{"JNDE-AB12CDE", ErrInvalidLength},  // 12
+		{"JNDE-AB12CDEF", ErrInvalidLength}, // 13
+		{"JNDE-AB12CD\x00", ErrInvalidLength},
+		{"JNDE-AB12\x00CD", ErrInvalidLength},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.s)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("Parse(%q): err = %v, want %v", tt.s, err, tt.want)
+		}
+		if _, berr := ParseBytes([]byte(tt.s)); !errors.Is(berr, tt.want) {
+			t.Errorf("ParseBytes(%q): err = %v, want %v", tt.s, berr, tt.want)
+		}
+	}
+
+	// the array holds at most maxLen characters, so Validate's upper bound is
+	// the full array and its lower bound a suffix of minSuffixLen
+	if err := Validate(MustParse("JNDE-AB12CD")); err != nil {
+		t.Errorf("Validate of a full array: %v", err)
+	}
+	if err := Validate(YULID{'J', 'N', 'D', 'E', '-', 'A', 'B', '1'}); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("Validate of an 8-character ID: err = %v, want ErrInvalidLength", err)
+	}
+}
+
+func TestValidateString(t *testing.T) {
+	for _, s := range []string{"JNDE-AB12", "JNDE-AB12CD"} {
+		if err := ValidateString(s); err != nil {
+			t.Errorf("ValidateString(%q): %v", s, err)
+		}
+		if err := ValidateBytes([]byte(s)); err != nil {
+			t.Errorf("ValidateBytes(%q): %v", s, err)
+		}
+	}
+	// Validate of the truncated array would accept the first 11 characters
+	for _, s := range []string{"JNDE-AB12CDE", "JNDE-AB12CD\x00"} {
+		if err := ValidateString(s); !errors.Is(err, ErrInvalidLength) {
+			t.Errorf("ValidateString(%q): err = %v, want ErrInvalidLength", s, err)
+		}
+		if err := ValidateBytes([]byte(s)); !errors.Is(err, ErrInvalidLength) {
+			t.Errorf("ValidateBytes(%q): err = %v, want ErrInvalidLength", s, err)
+		}
+	}
+	if err := ValidateString("JNDE.AB12", WithSeparator('.')); err != nil {
+		t.Errorf("ValidateString with options: %v", err)
+	}
+}
+
+func TestFolded(t *testing.T) {
+	variants := []YULID{
+		{'J', 'N', 'D', 'E', '-', 'e', 'd', '2', '4', 'h', 's'},
+		{'J', 'N', 'D', 'E', '-', 'E', 'd', '2', '4', 'H', 's'},
+		MustParse("JNDE-ED24HS"),
+	}
+	for _, v := range variants {
+		if got := v.Folded(); got != "JNDE-ED24HS" {
+			t.Errorf("Folded(%q) = %q, want %q", v, got, "JNDE-ED24HS")
+		}
+	}
+	if variants[0].String() != "JNDE-ed24hs" {
+		t.Errorf("Folded changed the receiver: String = %q", variants[0])
+	}
+	if MustParse("JNDE-ED24HS").Folded() == MustParse("JNDE-ED24HT").Folded() {
+		t.Error("distinct IDs fold to the same key")
+	}
+}
+
+// TestPooledEntropyNotShared checks that goroutines drawing from the entropy
+// pool never see the same buffered bytes: 32-character draws only repeat if a
+// buffer was handed out twice.
+func TestPooledEntropyNotShared(t *testing.T) {
+	const goroutines, draws = 16, 4000
+
+	results := make([][]string, goroutines)
+	var wg sync.WaitGroup
+	for g := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			buf := make([]byte, 32)
+			for i := 0; i < draws; i++ {
+				if err := generateRandom(buf, nil, alphanumeric); err != nil {
+					t.Error(err)
+					return
+				}
+				results[g] = append(results[g], string(buf))
+			}
+		}()
+	}
+	wg.Wait()
+
+	seen := make(map[string]bool, goroutines*draws)
+	for _, rs := range results {
+		for _, r := range rs {
+			if seen[r] {
+				t.Fatalf("entropy %q drawn twice", r)
+			}
+			seen[r] = true
+		}
+	}
+}
+
+func BenchmarkGenerateRandomParallel(b *testing.B) {
+	b.Run("pooled", func(b *testing.B) {
+		benchmarkGenerateRandomParallel(b, nil)
+	})
+	b.Run("unbuffered", func(b *testing.B) {
+		benchmarkGenerateRandomParallel(b, rand.Reader)
+	})
+}
+
+func benchmarkGenerateRandomParallel(b *testing.B, entropy io.Reader) {
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		buf := make([]byte, maxSuffixLen)
+		for pb.Next() {
+			if err := generateRandom(buf, entropy, alphanumeric); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestNewPrefixErrors(t *testing.T) {
+	tests := []struct {
+		prefix string
+		want   error
+	}{
+		{"", ErrEmptyPrefix},
+		{"JND", ErrPrefixTooShort},
+		{"JNDEX", ErrPrefixTooLong},
+		{"JN-E", ErrPrefixInvalidChar},
+		{"JNdE", ErrPrefixInvalidChar},
+		{"JNÉ", ErrPrefixInvalidChar}, // four bytes, one of them a two-byte rune
+	}
+	for _, tt := range tests {
+		_, err := New(tt.prefix)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("New(%q): err = %v, want %v", tt.prefix, err, tt.want)
+		}
+		if !errors.Is(err, ErrorInvalidInput) {
+			t.Errorf("New(%q): err = %v, which does not wrap ErrorInvalidInput", tt.prefix, err)
+		}
+	}
+	if !strings.Contains(ErrorInvalidInput.Error(), "alphanumeric") {
+		t.Errorf("ErrorInvalidInput = %q, want it to say alphanumeric", ErrorInvalidInput)
+	}
+}
+
+func TestValidateRejectsNonASCII(t *testing.T) {
+	// "É" is 0xc3 0x89 in UTF-8
+	ids := []YULID{
+		{'J', 'N', 'D', 'E', '-', 'A', 'B', '1', 0xc3, 0x89},
+		{'J', 'N', 0xc3, 0x89, '-', 'A', 'B', '1', '2'},
+		{'J', 'N', 'D', 'E', '-', 'A', 'B', '1', '2', 0xff},
+	}
+	for _, id := range ids {
+		if err := Validate(id); !errors.Is(err, ErrNonASCII) {
+			t.Errorf("Validate(%q): err = %v, want ErrNonASCII", id[:], err)
+		}
+	}
+	if _, err := Parse("JNDE-AB1É"); !errors.Is(err, ErrNonASCII) {
+		t.Errorf("Parse of a multi-byte suffix: err = %v, want ErrNonASCII", err)
+	}
+}
+
+func TestValidationErrorPosition(t *testing.T) {
+	tests := []struct {
+		yd    YULID
+		want  error
+		index int
+		char  byte
+	}{
+		{YULID{'J', 'N', '!', 'E', '-', 'A', 'B', '1', '2'}, ErrInvalidPrefix, 2, '!'},
+		{YULID{'J', 'N', 'D', 'E', '_', 'A', 'B', '1', '2'}, ErrInvalidSeparator, prefixLen, '_'},
+		{YULID{'J', 'N', 'D', 'E', '-', 'A', 'B', '1', 'c'}, ErrInvalidSuffix, 8, 'c'},
+		{YULID{'J', 'N', 'D', 'E', '-', 'A', 0xc3, 0x89, '2'}, ErrNonASCII, 6, 0xc3},
+	}
+	for _, tt := range tests {
+		err := Validate(tt.yd)
+		var ve *ValidationError
+		if !errors.As(err, &ve) || !errors.Is(err, tt.want) || ve.Index != tt.index || ve.Char != tt.char {
+			t.Errorf("Validate(%q) = %v, want %v at index %d", tt.yd[:], err, tt.want, tt.index)
+		}
+	}
+	if err := Validate(YULID{'J', 'N', 'D', 'E'}); err != ErrInvalidLength {
+		t.Errorf("Validate of a short ID = %v, want the bare ErrInvalidLength", err)
+	}
+}
+
+func TestGenerateRandomRejectsBiasedBytes(t *testing.T) {
+	// 252 is the largest multiple of 36 that fits in a byte; bytes from it up
+	// would favour the first four characters, so they are skipped
+	entropy := bytes.NewReader([]byte{252, 253, 254, 255, 0, 35, 36, 251, 1, 2, 3, 4})
+	dst := make([]byte, 6)
+	if err := generateRandom(dst, entropy, alphanumeric); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(dst), "A9A9BC"; got != want {
+		t.Fatalf("generateRandom = %q, want %q", got, want)
+	}
+}
+
+// bigIntSuffix draws each character with its own crypto/rand.Int call, the
+// approach generateRandom replaced
+func bigIntSuffix(dst []byte) error {
+	n := big.NewInt(int64(len(alphanumeric)))
+	for i := range dst {
+		c, err := rand.Int(rand.Reader, n)
+		if err != nil {
+			return err
+		}
+		dst[i] = alphanumeric[c.Int64()]
+	}
+	return nil
+}
+
+func BenchmarkSuffix(b *testing.B) {
+	b.Run("bulk", func(b *testing.B) {
+		b.ReportAllocs()
+		buf := make([]byte, maxSuffixLen)
+		for i := 0; i < b.N; i++ {
+			if err := generateRandom(buf, nil, alphanumeric); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("bigInt", func(b *testing.B) {
+		b.ReportAllocs()
+		buf := make([]byte, maxSuffixLen)
+		for i := 0; i < b.N; i++ {
+			if err := bigIntSuffix(buf); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package yulidcode
+
+import (
+	"errors"
+	"fmt"
+	"image"
+
+	yulid "github.com/mikills/yul_id"
+)
+
+const (
+	code128StartB    = 104 // start symbol selecting code set B
+	code128Stop      = 106
+	code128QuietZone = 10 // light modules required either side of the symbol
+)
+
+// code128Patterns holds the bar and space widths, in modules, of each Code 128
+// symbol value, starting with a bar
+var code128Patterns = [...]string{
+	"212222", "222122", "222221", "121223", "121322", "131222", "122213", "122312", "132212", "221213",
+	"221312", "231212", "112232", "122132", "122231", "113222", "123122", "123221", "223211", "221132",
+	"221231", "213212", "223112", "312131", "311222", "321122", "321221", "312212", "322112", "322211",
+	"212123", "212321", "232121", "111323", "131123", "131321", "112313", "132113", "132311", "211313",
+	"231113", "231311", "112133", "112331", "132131", "113123", "113321", "133121", "313121", "211331",
+	"231131", "213113", "213311", "213131", "311123", "311321", "331121", "312113", "312311", "332111",
+	"314111", "221411", "431111", "111224", "111422", "121124", "121421", "141122", "141221", "112214",
+	"112412", "122114", "122411", "142112", "142211", "241211", "221114", "413111", "241112", "134111",
+	"111242", "121142", "121241", "114212", "124112", "124211", "411212", "421112", "421211", "212141",
+	"214121", "412121", "111143", "111341", "131141", "114113", "114311", "411113", "411311", "113141",
+	"114131", "311141", "411131", "211412", "211214", "211232", "2331112",
+}
+
+// Barcode128PNG renders id as a Code 128 barcode, using code set B, in a
+// width×height PNG including the quiet zones. The bars are scaled to the
+// widest whole number of pixels per module that fits and centred.
+func Barcode128PNG(id yulid.YULID, width, height int) ([]byte, error) {
+	s := id.String()
+	if s == "" {
+		return nil, errors.New("cannot encode the zero YULID")
+	}
+
+	values := []int{code128StartB}
+	checksum := code128StartB
+	for i := 0; i < len(s); i++ {
+		if s[i] < ' ' || s[i] > '~' {
+			return nil, fmt.Errorf("character %q is outside Code 128 set B", s[i])
+		}
+		v := int(s[i] - ' ')
+		values = append(values, v)
+		checksum += v * (i + 1)
+	}
+	values = append(values, checksum%103, code128Stop)
+
+	var widths []int
+	modules := 0
+	for _, v := range values {
+		for _, w := range code128Patterns[v] {
+			widths = append(widths, int(w-'0'))
+			modules += int(w - '0')
+		}
+	}
+
+	scale := width / (modules + 2*code128QuietZone)
+	if scale < 1 || height < 1 {
+		return nil, fmt.Errorf("%w: the barcode needs at least %d pixels of width", ErrTooSmall, modules+2*code128QuietZone)
+	}
+
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	x := (width - scale*modules) / 2
+	for i, w := range widths {
+		if i%2 == 0 { // bars and spaces alternate, starting with a bar
+			fillRect(img, x, 0, w*scale, height)
+		}
+		x += w * scale
+	}
+	return encodePNG(img)
+}
@@ -0,0 +1,68 @@
+package yulidcode
+
+import (
+	"bytes"
+	"errors"
+	"image/png"
+	"strings"
+	"testing"
+
+	yulid "github.com/mikills/yul_id"
+)
+
+// code128Golden is "JNDE-AB12CD" in code set B without quiet zones, as
+// produced by the ZXing encoder
+const code128Golden = "##.#..#....#.##.###...#.###...##.#.##...#...#...##.#...#..##.###..#.#...##...#...#.##...#..###..##.##..###..#.#...#...##.#.##...#...#..#..####.##...###.#.##"
+
+// barcodeModules renders id at one pixel per module and returns its middle
+// row without the quiet zones
+func barcodeModules(t *testing.T, id yulid.YULID) string {
+	t.Helper()
+	width := len(code128Golden) + 2*code128QuietZone
+	data, err := Barcode128PNG(id, width, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var row strings.Builder
+	for x := img.Bounds().Min.X; x < img.Bounds().Max.X; x++ {
+		row.WriteByte(".#"[b2i(isDark(img.At(x, 5)))])
+	}
+	return strings.Trim(row.String(), ".")
+}
+
+func TestBarcode128Golden(t *testing.T) {
+	if got := barcodeModules(t, yulid.MustParse("JNDE-AB12CD")); got != code128Golden {
+		t.Errorf("Barcode128PNG modules =\n%s\nwant\n%s", got, code128Golden)
+	}
+}
+
+func TestBarcode128Checksum(t *testing.T) {
+	// start B 104, then each character's value less 32 weighted by position:
+	// 104 + 42·1 + 46·2 + 36·3 + 37·4 + 13·5 + 33·6 + 34·7 + 17·8 + 18·9 +
+	// 35·10 + 36·11 = 2039, and 2039 mod 103 = 82
+	modules := barcodeModules(t, yulid.MustParse("JNDE-AB12CD"))
+	const checkAt = 12 * 11 // after the start symbol and 11 characters
+	check := modules[checkAt : checkAt+11]
+
+	var want strings.Builder
+	for i, w := range code128Patterns[82] {
+		want.WriteString(strings.Repeat(string(".#"[1-i%2]), int(w-'0')))
+	}
+	if check != want.String() {
+		t.Errorf("check symbol = %s, want %s for value 82", check, want.String())
+	}
+}
+
+func TestBarcode128PNGErrors(t *testing.T) {
+	if _, err := Barcode128PNG(yulid.MustParse("JNDE-AB12CD"), len(code128Golden), 10); !errors.Is(err, ErrTooSmall) {
+		t.Errorf("Barcode128PNG without room for the quiet zones: err = %v, want ErrTooSmall", err)
+	}
+	if _, err := Barcode128PNG(yulid.YULID{}, 400, 10); err == nil {
+		t.Error("Barcode128PNG encoded the zero YULID")
+	}
+}
@@ -0,0 +1,546 @@
+// Package yulidcode renders YULIDs as scannable codes for labels: QR codes
+// with QRCodePNG and Code 128 barcodes with Barcode128PNG. Both embed the
+// canonical string form, so any scanner reads back an ID that Parse accepts.
+//
+// A YULID fits in the smallest QR code, version 1 (21×21 modules), using QR
+// alphanumeric mode at error correction level M, which tolerates about 15%
+// damage. DecodeQR reads back the images QRCodePNG produces; it is meant for
+// tests and label verification and is not a general QR scanner.
+package yulidcode
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+
+	yulid "github.com/mikills/yul_id"
+)
+
+var (
+	ErrTooSmall = errors.New("image size is too small for the code")
+	ErrNoCode   = errors.New("no readable code found in the image")
+)
+
+const (
+	qrSize       = 21 // modules per side of a version 1 QR code
+	qrQuietZone  = 4  // light modules required around the symbol
+	qrDataBytes  = 16 // data codewords of version 1 at level M
+	qrECBytes    = 10 // error correction codewords of version 1 at level M
+	qrECLevelM   = 0  // format information bits for error correction level M
+	qrModeAlnum  = 2  // mode indicator for alphanumeric mode
+	qrCountBits  = 9  // character count bits for alphanumeric mode in versions 1-9
+	qrFormatMask = 0x5412
+
+	qrAlphanumeric = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+)
+
+// qrMatrix is a QR symbol; true modules are dark
+type qrMatrix [qrSize][qrSize]bool
+
+// QRCodePNG renders id as a QR code in a size×size PNG, including the quiet
+// zone. size must leave at least one pixel per module: 29 or more.
+func QRCodePNG(id yulid.YULID, size int) ([]byte, error) {
+	payload, err := id.QRPayload()
+	if err != nil {
+		return nil, err
+	}
+	if payload == "" {
+		return nil, errors.New("cannot encode the zero YULID")
+	}
+	scale := size / (qrSize + 2*qrQuietZone)
+	if scale < 1 {
+		return nil, fmt.Errorf("%w: a QR code needs at least %d pixels", ErrTooSmall, qrSize+2*qrQuietZone)
+	}
+
+	m, function := qrFunctionPatterns()
+	data := qrEncodeData(payload)
+	codewords := append(data, reedSolomon(data, qrECBytes)...)
+	qrPlaceData(&m, &function, codewords)
+
+	// choose the mask with the lowest penalty, as the standard requires
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		candidate := m
+		qrApplyMask(&candidate, &function, mask)
+		qrDrawFormat(&candidate, mask)
+		if p := qrPenalty(&candidate); bestPenalty < 0 || p < bestPenalty {
+			best, bestPenalty = mask, p
+		}
+	}
+	qrApplyMask(&m, &function, best)
+	qrDrawFormat(&m, best)
+
+	img := image.NewGray(image.Rect(0, 0, size, size))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	offset := (size - scale*qrSize) / 2
+	for y := 0; y < qrSize; y++ {
+		for x := 0; x < qrSize; x++ {
+			if m[y][x] {
+				fillRect(img, offset+x*scale, offset+y*scale, scale, scale)
+			}
+		}
+	}
+	return encodePNG(img)
+}
+
+// DecodeQR reads a YULID from a PNG produced by QRCodePNG, checking the error
+// correction codewords and validating the result with opts as for
+// yulid.Parse. Codes that are damaged, rotated or not version 1 are reported
+// as ErrNoCode rather than corrected.
+func DecodeQR(data []byte, opts ...yulid.Option) (yulid.YULID, error) {
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return yulid.YULID{}, err
+	}
+
+	// the symbol's bounding box is set by the finder patterns' outer corners
+	b := img.Bounds()
+	minX, minY, maxX, maxY := b.Max.X, b.Max.Y, b.Min.X-1, b.Min.Y-1
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if isDark(img.At(x, y)) {
+				minX, minY, maxX, maxY = min(minX, x), min(minY, y), max(maxX, x), max(maxY, y)
+			}
+		}
+	}
+	width, height := maxX-minX+1, maxY-minY+1
+	if width <= 0 || width != height || width%qrSize != 0 {
+		return yulid.YULID{}, ErrNoCode
+	}
+	scale := width / qrSize
+
+	var m qrMatrix
+	for y := 0; y < qrSize; y++ {
+		for x := 0; x < qrSize; x++ {
+			m[y][x] = isDark(img.At(minX+x*scale+scale/2, minY+y*scale+scale/2))
+		}
+	}
+
+	mask, ok := qrReadFormat(&m)
+	if !ok {
+		return yulid.YULID{}, ErrNoCode
+	}
+	_, function := qrFunctionPatterns()
+	qrApplyMask(&m, &function, mask)
+	codewords := qrReadData(&m, &function)
+	if !bytes.Equal(reedSolomon(codewords[:qrDataBytes], qrECBytes), codewords[qrDataBytes:]) {
+		return yulid.YULID{}, fmt.Errorf("%w: error correction mismatch", ErrNoCode)
+	}
+
+	payload, ok := qrDecodeData(codewords[:qrDataBytes])
+	if !ok {
+		return yulid.YULID{}, ErrNoCode
+	}
+	return yulid.Parse(payload, opts...)
+}
+
+// qrFunctionPatterns returns a matrix holding the finder and timing patterns
+// and the dark module, and the set of modules reserved for them and for
+// format information
+func qrFunctionPatterns() (m, function qrMatrix) {
+	set := func(x, y int, dark bool) {
+		m[y][x] = dark
+		function[y][x] = true
+	}
+
+	for i := 0; i < qrSize; i++ {
+		set(6, i, i%2 == 0)
+		set(i, 6, i%2 == 0)
+	}
+	for _, c := range [][2]int{{3, 3}, {qrSize - 4, 3}, {3, qrSize - 4}} {
+		for dy := -4; dy <= 4; dy++ {
+			for dx := -4; dx <= 4; dx++ {
+				x, y := c[0]+dx, c[1]+dy
+				if x < 0 || x >= qrSize || y < 0 || y >= qrSize {
+					continue
+				}
+				dist := max(abs(dx), abs(dy))
+				set(x, y, dist != 2 && dist != 4)
+			}
+		}
+	}
+
+	// reserve the format areas; qrDrawFormat fills them
+	for i := 0; i < 9; i++ {
+		function[8][i] = true
+		function[i][8] = true
+	}
+	for i := 0; i < 8; i++ {
+		function[8][qrSize-1-i] = true
+		function[qrSize-1-i][8] = true
+	}
+	set(8, qrSize-8, true) // the dark module
+	return m, function
+}
+
+// qrEncodeData returns the data codewords for payload in alphanumeric mode
+func qrEncodeData(payload string) []byte {
+	var w bitWriter
+	w.write(qrModeAlnum, 4)
+	w.write(len(payload), qrCountBits)
+	for i := 0; i+1 < len(payload); i += 2 {
+		w.write(45*strings.IndexByte(qrAlphanumeric, payload[i])+strings.IndexByte(qrAlphanumeric, payload[i+1]), 11)
+	}
+	if len(payload)%2 == 1 {
+		w.write(strings.IndexByte(qrAlphanumeric, payload[len(payload)-1]), 6)
+	}
+
+	// terminator, byte alignment, then alternating pad codewords
+	w.write(0, min(4, qrDataBytes*8-w.n))
+	w.write(0, (8-w.n%8)%8)
+	for pad := 0; len(w.bytes()) < qrDataBytes; pad++ {
+		w.write([]int{0xec, 0x11}[pad%2], 8)
+	}
+	return w.bytes()
+}
+
+// qrDecodeData reads an alphanumeric segment from data codewords
+func qrDecodeData(data []byte) (string, bool) {
+	r := bitReader{data: data}
+	if r.read(4) != qrModeAlnum {
+		return "", false
+	}
+	n := r.read(qrCountBits)
+	if 4+qrCountBits+11*(n/2)+6*(n%2) > len(data)*8 {
+		return "", false
+	}
+
+	var b strings.Builder
+	for i := 0; i+1 < n; i += 2 {
+		v := r.read(11)
+		if v >= 45*45 {
+			return "", false
+		}
+		b.WriteByte(qrAlphanumeric[v/45])
+		b.WriteByte(qrAlphanumeric[v%45])
+	}
+	if n%2 == 1 {
+		v := r.read(6)
+		if v >= 45 {
+			return "", false
+		}
+		b.WriteByte(qrAlphanumeric[v])
+	}
+	return b.String(), true
+}
+
+// qrZigzag calls visit for each data module in placement order: upward and
+// downward through column pairs from the right, skipping the timing column
+func qrZigzag(function *qrMatrix, visit func(x, y int)) {
+	for right := qrSize - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < qrSize; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if upward := (right+1)&2 == 0; upward {
+					y = qrSize - 1 - vert
+				}
+				if !function[y][x] {
+					visit(x, y)
+				}
+			}
+		}
+	}
+}
+
+// qrPlaceData writes codewords into the data modules, most significant bit first
+func qrPlaceData(m, function *qrMatrix, codewords []byte) {
+	i := 0
+	qrZigzag(function, func(x, y int) {
+		if i < len(codewords)*8 {
+			m[y][x] = codewords[i/8]>>(7-i%8)&1 == 1
+		}
+		i++
+	})
+}
+
+// qrReadData reads the codewords back out of the data modules
+func qrReadData(m, function *qrMatrix) []byte {
+	codewords := make([]byte, qrDataBytes+qrECBytes)
+	i := 0
+	qrZigzag(function, func(x, y int) {
+		if i < len(codewords)*8 && m[y][x] {
+			codewords[i/8] |= 1 << (7 - i%8)
+		}
+		i++
+	})
+	return codewords
+}
+
+// qrApplyMask inverts the data modules selected by mask
+func qrApplyMask(m, function *qrMatrix, mask int) {
+	for y := 0; y < qrSize; y++ {
+		for x := 0; x < qrSize; x++ {
+			if !function[y][x] && qrMasked(mask, x, y) {
+				m[y][x] = !m[y][x]
+			}
+		}
+	}
+}
+
+// qrMasked reports whether mask inverts the module at column x, row y
+func qrMasked(mask, x, y int) bool {
+	switch mask {
+	case 0:
+		return (x+y)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (x+y)%3 == 0
+	case 4:
+		return (x/3+y/2)%2 == 0
+	case 5:
+		return x*y%2+x*y%3 == 0
+	case 6:
+		return (x*y%2+x*y%3)%2 == 0
+	default:
+		return ((x+y)%2+x*y%3)%2 == 0
+	}
+}
+
+// qrFormatBits returns the 15 BCH-protected format bits for level M and mask
+func qrFormatBits(mask int) int {
+	data := qrECLevelM<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = rem<<1 ^ (rem>>9)*0x537
+	}
+	return (data<<10 | rem) ^ qrFormatMask
+}
+
+// qrFormatPositions returns the two copies of the format bit positions, as
+// (column, row) pairs indexed by bit
+func qrFormatPositions() (first, second [15][2]int) {
+	for i := 0; i < 6; i++ {
+		first[i] = [2]int{8, i}
+	}
+	first[6], first[7], first[8] = [2]int{8, 7}, [2]int{8, 8}, [2]int{7, 8}
+	for i := 9; i < 15; i++ {
+		first[i] = [2]int{14 - i, 8}
+	}
+	for i := 0; i < 8; i++ {
+		second[i] = [2]int{qrSize - 1 - i, 8}
+	}
+	for i := 8; i < 15; i++ {
+		second[i] = [2]int{8, qrSize - 15 + i}
+	}
+	return first, second
+}
+
+// qrDrawFormat writes both copies of the format information for mask
+func qrDrawFormat(m *qrMatrix, mask int) {
+	bits := qrFormatBits(mask)
+	first, second := qrFormatPositions()
+	for i := 0; i < 15; i++ {
+		dark := bits>>i&1 == 1
+		m[first[i][1]][first[i][0]] = dark
+		m[second[i][1]][second[i][0]] = dark
+	}
+}
+
+// qrReadFormat returns the mask whose level M format bits are nearest the
+// first copy in m, if within the three errors BCH can correct
+func qrReadFormat(m *qrMatrix) (int, bool) {
+	first, _ := qrFormatPositions()
+	read := 0
+	for i := 0; i < 15; i++ {
+		if m[first[i][1]][first[i][0]] {
+			read |= 1 << i
+		}
+	}
+
+	best, bestDist := 0, 16
+	for mask := 0; mask < 8; mask++ {
+		if d := popcount(read ^ qrFormatBits(mask)); d < bestDist {
+			best, bestDist = mask, d
+		}
+	}
+	return best, bestDist <= 3
+}
+
+// qrPenalty scores m by the standard's four penalty rules; lower is better
+func qrPenalty(m *qrMatrix) int {
+	penalty := 0
+	dark := 0
+
+	line := func(get func(i int) bool) {
+		// rule 1: runs of five or more same-coloured modules
+		run := 1
+		for i := 1; i <= qrSize; i++ {
+			if i < qrSize && get(i) == get(i-1) {
+				run++
+				continue
+			}
+			if run >= 5 {
+				penalty += run - 2
+			}
+			run = 1
+		}
+
+		// rule 3: finder-like 1:1:3:1:1 patterns with four light modules on
+		// one side, treating the area outside the symbol as light
+		at := func(i int) bool { return i >= 0 && i < qrSize && get(i) }
+		for i := -4; i < qrSize; i++ {
+			core := at(i) && !at(i+1) && at(i+2) && at(i+3) && at(i+4) && !at(i+5) && at(i+6)
+			if !core {
+				continue
+			}
+			if !at(i-1) && !at(i-2) && !at(i-3) && !at(i-4) {
+				penalty += 40
+			}
+			if !at(i+7) && !at(i+8) && !at(i+9) && !at(i+10) {
+				penalty += 40
+			}
+		}
+	}
+	for y := 0; y < qrSize; y++ {
+		line(func(x int) bool { return m[y][x] })
+		line(func(x int) bool { return m[x][y] })
+	}
+
+	for y := 0; y < qrSize; y++ {
+		for x := 0; x < qrSize; x++ {
+			if m[y][x] {
+				dark++
+			}
+			// rule 2: 2×2 blocks of one colour
+			if x+1 < qrSize && y+1 < qrSize &&
+				m[y][x] == m[y][x+1] && m[y][x] == m[y+1][x] && m[y][x] == m[y+1][x+1] {
+				penalty += 3
+			}
+		}
+	}
+
+	// rule 4: deviation of the dark proportion from 50%, in 5% steps
+	total := qrSize * qrSize
+	penalty += ((abs(dark*20-total*10)+total-1)/total - 1) * 10
+	return penalty
+}
+
+// reedSolomon returns the n error correction codewords for data over GF(256)
+// with the QR generator polynomial
+func reedSolomon(data []byte, n int) []byte {
+	// generator polynomial: the product of (x - α^i) for i in [0, n)
+	gen := make([]byte, n)
+	gen[n-1] = 1
+	root := byte(1)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			gen[j] = gfMul(gen[j], root)
+			if j+1 < n {
+				gen[j] ^= gen[j+1]
+			}
+		}
+		root = gfMul(root, 2)
+	}
+
+	rem := make([]byte, n)
+	for _, b := range data {
+		factor := b ^ rem[0]
+		copy(rem, rem[1:])
+		rem[n-1] = 0
+		for j := range rem {
+			rem[j] ^= gfMul(gen[j], factor)
+		}
+	}
+	return rem
+}
+
+// gfMul multiplies in GF(256) modulo the QR polynomial x^8+x^4+x^3+x^2+1
+func gfMul(x, y byte) byte {
+	var z int
+	for i := 7; i >= 0; i-- {
+		z = z<<1 ^ (z>>7)*0x11d
+		z ^= int(y>>i&1) * int(x)
+	}
+	return byte(z)
+}
+
+// bitWriter accumulates big-endian bit fields
+type bitWriter struct {
+	buf []byte
+	n   int // bits written
+}
+
+func (w *bitWriter) write(v, bits int) {
+	for i := bits - 1; i >= 0; i-- {
+		if w.n%8 == 0 {
+			w.buf = append(w.buf, 0)
+		}
+		if v>>i&1 == 1 {
+			w.buf[w.n/8] |= 1 << (7 - w.n%8)
+		}
+		w.n++
+	}
+}
+
+func (w *bitWriter) bytes() []byte {
+	return w.buf
+}
+
+// bitReader reads big-endian bit fields, yielding zeros past the end
+type bitReader struct {
+	data []byte
+	n    int // bits read
+}
+
+func (r *bitReader) read(bits int) int {
+	v := 0
+	for i := 0; i < bits; i++ {
+		bit := 0
+		if r.n/8 < len(r.data) {
+			bit = int(r.data[r.n/8] >> (7 - r.n%8) & 1)
+		}
+		v = v<<1 | bit
+		r.n++
+	}
+	return v
+}
+
+// fillRect paints a w×h dark rectangle at (x, y)
+func fillRect(img *image.Gray, x, y, w, h int) {
+	for dy := 0; dy < h; dy++ {
+		for dx := 0; dx < w; dx++ {
+			img.SetGray(x+dx, y+dy, color.Gray{})
+		}
+	}
+}
+
+// isDark reports whether c is closer to black than to white
+func isDark(c color.Color) bool {
+	return color.GrayModel.Convert(c).(color.Gray).Y < 0x80
+}
+
+func encodePNG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func popcount(n int) int {
+	c := 0
+	for ; n != 0; n &= n - 1 {
+		c++
+	}
+	return c
+}
@@ -0,0 +1,126 @@
+package yulidcode
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+	"testing"
+
+	yulid "github.com/mikills/yul_id"
+)
+
+func TestQRRoundTrip(t *testing.T) {
+	gens := make([]*yulid.Generator, 0, 3)
+	for _, n := range []int{4, 5, 6} {
+		g, err := yulid.NewGenerator(yulid.WithSuffixLength(n))
+		if err != nil {
+			t.Fatal(err)
+		}
+		gens = append(gens, g)
+	}
+
+	for i := 0; i < 300; i++ {
+		id, err := gens[i%len(gens)].New("JNDE")
+		if err != nil {
+			t.Fatal(err)
+		}
+		size := 29 + i%4*31 // one to four pixels per module
+		data, err := QRCodePNG(id, size)
+		if err != nil {
+			t.Fatalf("QRCodePNG(%q, %d): %v", id, size, err)
+		}
+		got, err := DecodeQR(data)
+		if err != nil {
+			t.Fatalf("DecodeQR of %q at %d pixels: %v", id, size, err)
+		}
+		if got != id {
+			t.Fatalf("DecodeQR = %q, want %q", got, id)
+		}
+	}
+}
+
+// qrGolden is the version 1-M symbol for "JNDE-AB12CD" with mask 3, as
+// produced by the ZXing encoder
+var qrGolden = [qrSize]string{
+	"#######.#..#..#######",
+	"#.....#.##..#.#.....#",
+	"#.###.#..###..#.###.#",
+	"#.###.#.#.###.#.###.#",
+	"#.###.#..#....#.###.#",
+	"#.....#..##...#.....#",
+	"#######.#.#.#.#######",
+	"........###..........",
+	"#.##.###.##...#..#.##",
+	"##.##..###..##..###.#",
+	"....#.#..#..#.#..#.#.",
+	"###.#...#...##....###",
+	"#.###.##..##.......#.",
+	"........#....#..#.##.",
+	"#######.###..########",
+	"#.....#.#..#####.#.#.",
+	"#.###.#..#.#..#..#.##",
+	"#.###.#.##...#..####.",
+	"#.###.#.#.##.#.###...",
+	"#.....#..####.###.#..",
+	"#######.#..##..#...#.",
+}
+
+func TestQRMatrixGolden(t *testing.T) {
+	const mask = 3
+	m, function := qrFunctionPatterns()
+	data := qrEncodeData("JNDE-AB12CD")
+	qrPlaceData(&m, &function, append(data, reedSolomon(data, qrECBytes)...))
+	qrApplyMask(&m, &function, mask)
+	qrDrawFormat(&m, mask)
+
+	for y, want := range qrGolden {
+		row := make([]byte, qrSize)
+		for x := range row {
+			row[x] = ".#"[b2i(m[y][x])]
+		}
+		if string(row) != want {
+			t.Errorf("row %2d = %s\n   want %s", y, row, want)
+		}
+	}
+	if got, ok := qrReadFormat(&m); !ok || got != mask {
+		t.Errorf("qrReadFormat = %d, %v; want %d", got, ok, mask)
+	}
+}
+
+func TestReedSolomonGolden(t *testing.T) {
+	// the "HELLO WORLD" version 1-M worked example of the Thonky QR code
+	// tutorial
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+	if got := qrEncodeData("HELLO WORLD"); !bytes.Equal(got, data) {
+		t.Errorf("qrEncodeData(HELLO WORLD) = %v, want %v", got, data)
+	}
+	if got := reedSolomon(data, qrECBytes); !bytes.Equal(got, want) {
+		t.Errorf("reedSolomon = %v, want %v", got, want)
+	}
+}
+
+func TestQRCodePNGErrors(t *testing.T) {
+	if _, err := QRCodePNG(yulid.MustParse("JNDE-AB12CD"), 28); !errors.Is(err, ErrTooSmall) {
+		t.Errorf("QRCodePNG at 28 pixels: err = %v, want ErrTooSmall", err)
+	}
+	if _, err := QRCodePNG(yulid.YULID{}, 100); err == nil {
+		t.Error("QRCodePNG encoded the zero YULID")
+	}
+
+	var solid bytes.Buffer
+	if err := png.Encode(&solid, image.NewGray(image.Rect(0, 0, 50, 50))); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DecodeQR(solid.Bytes()); !errors.Is(err, ErrNoCode) {
+		t.Errorf("DecodeQR of a solid image: err = %v, want ErrNoCode", err)
+	}
+}
+
+func b2i(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,280 @@
+// Package yulidhttp serves YULID minting and validation over HTTP, for teams
+// that want a small internal ID service rather than linking the library.
+//
+// The handler exposes two endpoints:
+//
+//	POST /yulids                 mint a YULID from a prefix or a full name
+//	GET  /yulids/{id}/validate   report whether id is a valid YULID
+//
+// A POST body is either plain text or, with Content-Type application/json, an
+// object with a "prefix" or "name" field. A plain-text body that is already a
+// valid 4-character prefix is used as is; anything else is treated as a name
+// and passed to NewFromName. Responses are JSON unless the Accept header
+// prefers text/plain.
+package yulidhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	yulid "github.com/mikills/yul_id"
+)
+
+// maxBodySize bounds POST bodies; names and prefixes are short
+const maxBodySize = 1 << 10
+
+// Limiter decides whether a request may proceed, so callers can plug in
+// per-client or global rate limiting. Requests it refuses get 429 Too Many
+// Requests.
+type Limiter interface {
+	Allow(r *http.Request) bool
+}
+
+// LimiterFunc adapts an ordinary function to the Limiter interface
+type LimiterFunc func(r *http.Request) bool
+
+// Allow calls f(r)
+func (f LimiterFunc) Allow(r *http.Request) bool {
+	return f(r)
+}
+
+// Option configures a Handler
+type Option func(*Handler)
+
+// WithGenerator sets the Generator used to mint and validate IDs, so the
+// service can issue checksummed or otherwise customised formats. The default
+// is the standard format.
+func WithGenerator(g *yulid.Generator) Option {
+	return func(h *Handler) {
+		h.gen = g
+	}
+}
+
+// WithLimiter sets a Limiter consulted before every request. By default no
+// requests are limited.
+func WithLimiter(l Limiter) Option {
+	return func(h *Handler) {
+		h.limiter = l
+	}
+}
+
+// Handler is an http.Handler serving the YULID endpoints. It is safe for
+// concurrent use.
+type Handler struct {
+	gen     *yulid.Generator
+	limiter Limiter
+	mux     *http.ServeMux
+}
+
+// NewHandler returns a Handler configured by opts
+func NewHandler(opts ...Option) (*Handler, error) {
+	h := &Handler{}
+	for _, opt := range opts {
+		opt(h)
+	}
+	if h.gen == nil {
+		g, err := yulid.NewGenerator()
+		if err != nil {
+			return nil, err
+		}
+		h.gen = g
+	}
+
+	h.mux = http.NewServeMux()
+	h.mux.HandleFunc("POST /yulids", h.mint)
+	h.mux.HandleFunc("GET /yulids/{id}/validate", h.validate)
+	return h, nil
+}
+
+// ServeHTTP applies the Limiter, then routes the request
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.limiter != nil && !h.limiter.Allow(r) {
+		writeError(w, r, http.StatusTooManyRequests, errors.New("rate limit exceeded"))
+		return
+	}
+	h.mux.ServeHTTP(w, r)
+}
+
+// mintRequest is the JSON form of a POST /yulids body
+type mintRequest struct {
+	Prefix string `json:"prefix"`
+	Name   string `json:"name"`
+}
+
+// mintResponse is the JSON result of POST /yulids
+type mintResponse struct {
+	ID string `json:"id"`
+}
+
+// validateResponse is the JSON result of GET /yulids/{id}/validate
+type validateResponse struct {
+	ID    string `json:"id"`
+	Valid bool   `json:"valid"`
+	Error string `json:"error,omitempty"`
+}
+
+// errorResponse is the JSON body of a failed request
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (h *Handler) mint(w http.ResponseWriter, r *http.Request) {
+	req, err := readMintRequest(r)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	switch {
+	case req.Prefix != "" && req.Name != "":
+		writeError(w, r, http.StatusBadRequest, errors.New(`only one of "prefix" and "name" may be given`))
+		return
+	case req.Prefix == "" && req.Name == "":
+		writeError(w, r, http.StatusBadRequest, errors.New("a prefix or name is required"))
+		return
+	}
+
+	var id yulid.YULID
+	if req.Prefix != "" {
+		id, err = h.gen.NewContext(r.Context(), req.Prefix)
+	} else {
+		id, err = h.gen.NewFromName(req.Name)
+	}
+	if err != nil {
+		writeError(w, r, statusFor(err), err)
+		return
+	}
+
+	if prefersText(r) {
+		writeText(w, http.StatusCreated, id.String())
+		return
+	}
+	writeJSON(w, http.StatusCreated, mintResponse{ID: id.String()})
+}
+
+// readMintRequest decodes a POST /yulids body according to its content type
+func readMintRequest(r *http.Request) (mintRequest, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+	if err != nil {
+		return mintRequest{}, err
+	}
+	if len(body) > maxBodySize {
+		return mintRequest{}, fmt.Errorf("request body exceeds %d bytes", maxBodySize)
+	}
+
+	var req mintRequest
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "application/json" {
+		if err := json.Unmarshal(body, &req); err != nil {
+			return mintRequest{}, fmt.Errorf("invalid JSON body: %w", err)
+		}
+		return req, nil
+	}
+
+	if s := strings.TrimSpace(string(body)); isPrefix(s) {
+		req.Prefix = s
+	} else {
+		req.Name = s
+	}
+	return req, nil
+}
+
+func (h *Handler) validate(w http.ResponseWriter, r *http.Request) {
+	res := validateResponse{ID: r.PathValue("id"), Valid: true}
+	if _, err := h.gen.Parse(res.ID); err != nil {
+		res.Valid, res.Error = false, err.Error()
+	}
+
+	if prefersText(r) {
+		if res.Valid {
+			writeText(w, http.StatusOK, "valid")
+		} else {
+			writeText(w, http.StatusOK, "invalid: "+res.Error)
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+// statusFor maps a generation error onto an HTTP status: malformed or refused
+// prefixes and names are the client's fault, an exhausted keyspace or failing
+// entropy source is the server's
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, yulid.ErrExhausted):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, yulid.ErrEntropy):
+		return http.StatusInternalServerError
+	case errors.Is(err, yulid.ErrorInvalidInput),
+		errors.Is(err, yulid.ErrInvalidName),
+		errors.Is(err, yulid.ErrPrefixNotAllowed),
+		errors.Is(err, yulid.ErrReservedPrefix):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// isPrefix reports whether s is a well-formed YULID prefix
+func isPrefix(s string) bool {
+	if len(s) != 4 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if (s[i] < 'A' || s[i] > 'Z') && (s[i] < '0' || s[i] > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// prefersText reports whether the Accept header ranks text/plain above JSON.
+// A missing header, or one that ranks them equally, gets JSON.
+func prefersText(r *http.Request) bool {
+	var textQ, jsonQ float64
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		switch mediaType {
+		case "text/plain":
+			textQ = max(textQ, q)
+		case "application/json":
+			jsonQ = max(jsonQ, q)
+		}
+	}
+	return textQ > jsonQ
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeText(w http.ResponseWriter, status int, s string) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(status)
+	fmt.Fprintln(w, s)
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if prefersText(r) {
+		writeText(w, status, err.Error())
+		return
+	}
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
@@ -0,0 +1,193 @@
+package yulidhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	yulid "github.com/mikills/yul_id"
+)
+
+func newTestHandler(t *testing.T, opts ...Option) *Handler {
+	t.Helper()
+	h, err := NewHandler(opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+func serve(h http.Handler, method, target, contentType, accept, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	if contentType != "" {
+		r.Header.Set("Content-Type", contentType)
+	}
+	if accept != "" {
+		r.Header.Set("Accept", accept)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestMint(t *testing.T) {
+	h := newTestHandler(t)
+
+	tests := []struct {
+		name, contentType, body string
+		wantPrefix              string
+	}{
+		{"JSON prefix", "application/json", `{"prefix":"JNDE"}`, "JNDE"},
+		{"text prefix", "", "JNDE\n", "JNDE"},
+		{"JSON name", "application/json", `{"name":"Jane Doe"}`, ""},
+		{"text name", "text/plain", "Jane Doe", ""},
+	}
+	for _, tt := range tests {
+		w := serve(h, http.MethodPost, "/yulids", tt.contentType, "", tt.body)
+		if w.Code != http.StatusCreated {
+			t.Errorf("%s: status %d, want 201; body %s", tt.name, w.Code, w.Body)
+			continue
+		}
+		if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("%s: Content-Type %q", tt.name, ct)
+		}
+		var res mintResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		id, err := yulid.Parse(res.ID)
+		if err != nil {
+			t.Errorf("%s: minted %q: %v", tt.name, res.ID, err)
+		} else if tt.wantPrefix != "" && id.Prefix() != tt.wantPrefix {
+			t.Errorf("%s: minted %q, want prefix %s", tt.name, id, tt.wantPrefix)
+		}
+	}
+}
+
+func TestMintText(t *testing.T) {
+	w := serve(newTestHandler(t), http.MethodPost, "/yulids", "", "text/plain, application/json;q=0.5", "JNDE")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status %d, want 201", w.Code)
+	}
+	if id, err := yulid.Parse(strings.TrimSpace(w.Body.String())); err != nil || id.Prefix() != "JNDE" {
+		t.Fatalf("body %q: %v", w.Body, err)
+	}
+}
+
+func TestMintRejectsBadRequests(t *testing.T) {
+	g, err := yulid.NewGenerator(yulid.WithReservedPrefixes(yulid.DefaultReservedPrefixes...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := newTestHandler(t, WithGenerator(g))
+
+	tests := []struct {
+		name, contentType, body string
+	}{
+		{"reserved prefix", "application/json", `{"prefix":"ADMN"}`},
+		{"malformed prefix", "application/json", `{"prefix":"jn!"}`},
+		{"both fields", "application/json", `{"prefix":"JNDE","name":"Jane Doe"}`},
+		{"no fields", "application/json", `{}`},
+		{"invalid JSON", "application/json", `{"prefix":`},
+		{"empty text", "text/plain", ""},
+		{"name without letters", "text/plain", "!!!"},
+		{"oversized body", "text/plain", strings.Repeat("A", maxBodySize+1)},
+	}
+	for _, tt := range tests {
+		w := serve(h, http.MethodPost, "/yulids", tt.contentType, "", tt.body)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400; body %s", tt.name, w.Code, w.Body)
+			continue
+		}
+		var res errorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil || res.Error == "" {
+			t.Errorf("%s: error body %s: %v", tt.name, w.Body, err)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	h := newTestHandler(t)
+
+	tests := []struct {
+		id    string
+		valid bool
+	}{
+		{"JNDE-AB12CD", true},
+		{"JNDE-AB12", true},
+		{"JNDE-ab12cd", false},
+		{"JNDE_AB12CD", false},
+		{"JNDE-AB1", false},
+	}
+	for _, tt := range tests {
+		w := serve(h, http.MethodGet, "/yulids/"+tt.id+"/validate", "", "", "")
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: status %d, want 200", tt.id, w.Code)
+			continue
+		}
+		var res validateResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Errorf("%s: %v", tt.id, err)
+			continue
+		}
+		if res.ID != tt.id || res.Valid != tt.valid || (res.Error == "") != tt.valid {
+			t.Errorf("%s: response %+v, want valid = %v", tt.id, res, tt.valid)
+		}
+	}
+
+	w := serve(h, http.MethodGet, "/yulids/JNDE-AB1/validate", "", "text/plain", "")
+	if got := w.Body.String(); !strings.HasPrefix(got, "invalid: ") {
+		t.Errorf("text validation of an invalid ID = %q", got)
+	}
+}
+
+func TestValidateUsesGenerator(t *testing.T) {
+	g, err := yulid.NewGenerator(yulid.WithSeparator('.'))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := newTestHandler(t, WithGenerator(g))
+
+	w := serve(h, http.MethodGet, "/yulids/JNDE.AB12CD/validate", "", "text/plain", "")
+	if got := strings.TrimSpace(w.Body.String()); got != "valid" {
+		t.Errorf("validation under the Generator's format = %q", got)
+	}
+	w = serve(h, http.MethodGet, "/yulids/JNDE-AB12CD/validate", "", "text/plain", "")
+	if got := w.Body.String(); !strings.HasPrefix(got, "invalid: ") {
+		t.Errorf("validation of the standard format = %q", got)
+	}
+}
+
+func TestWrongMethod(t *testing.T) {
+	h := newTestHandler(t)
+	for _, req := range [][2]string{
+		{http.MethodGet, "/yulids"},
+		{http.MethodPut, "/yulids"},
+		{http.MethodPost, "/yulids/JNDE-AB12CD/validate"},
+		{http.MethodDelete, "/yulids/JNDE-AB12CD/validate"},
+	} {
+		w := serve(h, req[0], req[1], "", "", "")
+		if w.Code != http.StatusMethodNotAllowed {
+			t.Errorf("%s %s: status %d, want 405", req[0], req[1], w.Code)
+		}
+	}
+	if w := serve(h, http.MethodGet, "/other", "", "", ""); w.Code != http.StatusNotFound {
+		t.Errorf("GET /other: status %d, want 404", w.Code)
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	allow := false
+	h := newTestHandler(t, WithLimiter(LimiterFunc(func(*http.Request) bool { return allow })))
+
+	if w := serve(h, http.MethodPost, "/yulids", "", "", "JNDE"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("refused request: status %d, want 429", w.Code)
+	}
+	allow = true
+	if w := serve(h, http.MethodPost, "/yulids", "", "", "JNDE"); w.Code != http.StatusCreated {
+		t.Errorf("allowed request: status %d, want 201", w.Code)
+	}
+}
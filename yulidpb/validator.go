@@ -0,0 +1,138 @@
+package yulidpb
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	yulid "github.com/mikills/yul_id"
+)
+
+// FieldError reports a malformed YULID field found by a Validator. Path names
+// the field by its Go field names from the root message, such as
+// "Customer" or "Items[2].Owner". gRPC servers will usually map it to
+// codes.InvalidArgument.
+type FieldError struct {
+	Path string
+	Err  error
+}
+
+func (e *FieldError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("invalid YULID: %v", e.Err)
+	}
+	return fmt.Sprintf("invalid YULID in field %s: %v", e.Path, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Validator checks every YULID field in a message, however deeply nested,
+// including those in repeated fields, map values and oneofs. Unset fields are
+// not errors; whether a field is required is for the service to decide.
+//
+// A Validator is safe for concurrent use.
+type Validator struct {
+	typ reflect.Type
+	gen *yulid.Generator
+}
+
+// NewValidator returns a Validator for the generated YULID message type of
+// sample, which is only used for its type, so a typed nil is enough:
+//
+//	v, err := yulidpb.NewValidator((*yulidv1.YULID)(nil))
+//
+// opts describe the expected format, as for yulid.Parse.
+func NewValidator(sample Message, opts ...yulid.Option) (*Validator, error) {
+	if sample == nil {
+		return nil, fmt.Errorf("yulidpb: sample message must not be nil")
+	}
+	g, err := yulid.NewGenerator(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Validator{typ: reflect.TypeOf(sample), gen: g}, nil
+}
+
+// Validate returns a *FieldError for a malformed YULID field in msg, or nil if
+// there is none
+func (v *Validator) Validate(msg any) error {
+	return v.walk(reflect.ValueOf(msg), "")
+}
+
+// Intercept validates req and calls handler only if it is well formed. Its
+// signature matches the tail of grpc.UnaryServerInterceptor, so wiring it in
+// takes one closure:
+//
+//	grpc.UnaryInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, h grpc.UnaryHandler) (any, error) {
+//		return v.Intercept(ctx, req, h)
+//	})
+func (v *Validator) Intercept(ctx context.Context, req any, handler func(context.Context, any) (any, error)) (any, error) {
+	if err := v.Validate(req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// walk validates val, found at path, and everything reachable from it through
+// exported fields
+func (v *Validator) walk(val reflect.Value, path string) error {
+	if !val.IsValid() {
+		return nil
+	}
+	if val.Type() == v.typ {
+		if val.Kind() == reflect.Pointer && val.IsNil() {
+			return nil
+		}
+		if _, err := v.gen.Parse(val.Interface().(Message).GetValue()); err != nil {
+			return &FieldError{Path: path, Err: err}
+		}
+		return nil
+	}
+
+	switch val.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if val.IsNil() {
+			return nil
+		}
+		return v.walk(val.Elem(), path)
+	case reflect.Struct:
+		t := val.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			if err := v.walk(val.Field(i), join(path, f.Name)); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if val.Type().Elem().Kind() == reflect.Uint8 {
+			return nil // bytes fields cannot hold messages
+		}
+		for i := 0; i < val.Len(); i++ {
+			if err := v.walk(val.Index(i), path+"["+strconv.Itoa(i)+"]"); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := val.MapRange()
+		for iter.Next() {
+			if err := v.walk(iter.Value(), fmt.Sprintf("%s[%v]", path, iter.Key())); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// join appends a field name to a path
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,172 @@
+package yulidpb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	yulid "github.com/mikills/yul_id"
+)
+
+// pbYULID stands in for the generated yulid.v1.YULID type
+type pbYULID struct {
+	Value string
+}
+
+func (x *pbYULID) GetValue() string {
+	if x == nil {
+		return ""
+	}
+	return x.Value
+}
+
+// otherID has the same shape as pbYULID but is a different message type
+type otherID struct {
+	Value string
+}
+
+func (x *otherID) GetValue() string {
+	return x.Value
+}
+
+type lineItem struct {
+	Owner *pbYULID
+	SKU   string
+}
+
+type order struct {
+	Customer *pbYULID
+	Items    []*lineItem
+	Tags     map[string]*pbYULID
+	Payload  any
+	Raw      []byte
+	Legacy   *otherID
+	Note     string
+
+	internal *pbYULID
+}
+
+func newValidator(t *testing.T, opts ...yulid.Option) *Validator {
+	t.Helper()
+	v, err := NewValidator((*pbYULID)(nil), opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func valid(s string) *pbYULID {
+	return &pbYULID{Value: s}
+}
+
+func TestValidatorValid(t *testing.T) {
+	v := newValidator(t)
+	msg := &order{
+		Customer: valid("JNDE-AB12CD"),
+		Items:    []*lineItem{{Owner: valid("ACME-AB12")}, {SKU: "not a YULID"}, nil},
+		Tags:     map[string]*pbYULID{"billing": valid("BILL-XY99")},
+		Payload:  valid("JNDE-ZZ00"),
+		Raw:      []byte("JNDE_AB12CD"),
+		Legacy:   &otherID{Value: "not a YULID"},
+		Note:     "JNDE_AB12CD",
+		internal: valid("bad"),
+	}
+	if err := v.Validate(msg); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	if err := v.Validate(&order{}); err != nil {
+		t.Fatalf("Validate of an empty message: %v", err)
+	}
+	if err := v.Validate(nil); err != nil {
+		t.Fatalf("Validate(nil): %v", err)
+	}
+}
+
+func TestValidatorInvalid(t *testing.T) {
+	v := newValidator(t)
+
+	tests := []struct {
+		name string
+		msg  any
+		path string
+	}{
+		{"root", valid("jnde-ab12cd"), ""},
+		{"field", &order{Customer: valid("JNDE_AB12CD")}, "Customer"},
+		{"empty value", &order{Customer: valid("")}, "Customer"},
+		{"nested repeated", &order{Items: []*lineItem{{Owner: valid("JNDE-AB12")}, {Owner: valid("JNDE-AB1")}}}, "Items[1].Owner"},
+		{"map value", &order{Tags: map[string]*pbYULID{"billing": valid("BILL-X")}}, "Tags[billing]"},
+		{"interface", &order{Payload: valid("JNDE-AB12CD!")}, "Payload"},
+		{"by value", order{Customer: valid("JNDE")}, "Customer"},
+	}
+	for _, tt := range tests {
+		err := v.Validate(tt.msg)
+		var fe *FieldError
+		if !errors.As(err, &fe) {
+			t.Errorf("%s: err = %v, want a *FieldError", tt.name, err)
+			continue
+		}
+		if fe.Path != tt.path {
+			t.Errorf("%s: path %q, want %q", tt.name, fe.Path, tt.path)
+		}
+		if errors.Unwrap(err) == nil {
+			t.Errorf("%s: FieldError has no cause", tt.name)
+		}
+	}
+
+	err := v.Validate(&order{Customer: valid("JNDE-ab12cd")})
+	if !errors.Is(err, yulid.ErrInvalidSuffix) {
+		t.Errorf("err = %v, want it to wrap ErrInvalidSuffix", err)
+	}
+}
+
+func TestValidatorOptions(t *testing.T) {
+	v := newValidator(t, yulid.WithSeparator('.'))
+	if err := v.Validate(&order{Customer: valid("JNDE.AB12CD")}); err != nil {
+		t.Errorf("Validate under the configured format: %v", err)
+	}
+	if err := v.Validate(&order{Customer: valid("JNDE-AB12CD")}); err == nil {
+		t.Error("Validate accepted the standard format under a '.' separator")
+	}
+}
+
+func TestNewValidatorErrors(t *testing.T) {
+	if _, err := NewValidator(nil); err == nil {
+		t.Error("NewValidator accepted a nil sample")
+	}
+	if _, err := NewValidator((*pbYULID)(nil), yulid.WithSuffixLength(9)); !errors.Is(err, yulid.ErrInvalidOption) {
+		t.Errorf("NewValidator with a bad option: err = %v, want ErrInvalidOption", err)
+	}
+}
+
+func TestIntercept(t *testing.T) {
+	v := newValidator(t)
+	called := false
+	handler := func(ctx context.Context, req any) (any, error) {
+		called = true
+		return "ok", nil
+	}
+
+	if res, err := v.Intercept(context.Background(), &order{Customer: valid("JNDE-AB12CD")}, handler); err != nil || res != "ok" || !called {
+		t.Fatalf("Intercept of a valid request = %v, %v; handler called %v", res, err, called)
+	}
+	called = false
+	if _, err := v.Intercept(context.Background(), &order{Customer: valid("JNDE")}, handler); err == nil || called {
+		t.Fatalf("Intercept of an invalid request: err = %v, handler called %v", err, called)
+	}
+}
+
+func TestFromProto(t *testing.T) {
+	id := yulid.MustParse("JNDE-AB12CD")
+	if got, err := FromProto(valid(ToProto(id))); err != nil || got != id {
+		t.Fatalf("FromProto(ToProto(%q)) = %q, %v", id, got, err)
+	}
+	if _, err := FromProto((*pbYULID)(nil)); !errors.Is(err, ErrMissing) {
+		t.Errorf("FromProto of a typed nil: err = %v, want ErrMissing", err)
+	}
+	if _, err := FromProto(nil); !errors.Is(err, ErrMissing) {
+		t.Errorf("FromProto(nil): err = %v, want ErrMissing", err)
+	}
+	if _, err := FromProto(valid("JNDE")); err == nil || errors.Is(err, ErrMissing) {
+		t.Errorf("FromProto of a malformed value: err = %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+// Package yulidpb carries YULIDs across gRPC and protobuf boundaries.
+//
+// yulid.proto defines a yulid.v1.YULID message wrapping the canonical string
+// form. Generate Go code for it alongside your other protos; this package
+// works with the generated type through the Message interface and so adds no
+// protobuf or gRPC dependency to the module.
+//
+// Conversion at the boundary goes through ToProto and FromProto:
+//
+//	msg.Customer = &yulidv1.YULID{Value: yulidpb.ToProto(customer.ID)}
+//	id, err := yulidpb.FromProto(msg.Customer)
+//
+// A Validator rejects requests carrying malformed YULID fields before they
+// reach the service implementation; see Validator.Intercept.
+package yulidpb
+
+import (
+	"errors"
+	"reflect"
+
+	yulid "github.com/mikills/yul_id"
+)
+
+// ErrMissing is returned by FromProto for a nil message
+var ErrMissing = errors.New("YULID field is not set")
+
+// Message is implemented by the Go type generated from the yulid.v1.YULID
+// message
+type Message interface {
+	GetValue() string
+}
+
+// ToProto returns the value field of the yulid.v1.YULID message for id
+func ToProto(id yulid.YULID) string {
+	return id.Proto()
+}
+
+// FromProto converts a yulid.v1.YULID message into a YULID, validating it. It
+// returns ErrMissing if m is nil, so unset fields are distinguished from
+// malformed ones.
+func FromProto(m Message, opts ...yulid.Option) (yulid.YULID, error) {
+	if isNil(m) {
+		return yulid.YULID{}, ErrMissing
+	}
+	return yulid.Parse(m.GetValue(), opts...)
+}
+
+// isNil reports whether m is nil or a typed nil pointer, as an unset message
+// field is
+func isNil(m Message) bool {
+	if m == nil {
+		return true
+	}
+	v := reflect.ValueOf(m)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
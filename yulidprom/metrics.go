@@ -0,0 +1,163 @@
+// Package yulidprom exports Generator activity as Prometheus metrics.
+//
+// A Metrics value is a yulid.Observer that accumulates counters and a latency
+// histogram, and an http.Handler serving them in the Prometheus text
+// exposition format, so it can be scraped directly without the Prometheus
+// client library:
+//
+//	m := yulidprom.NewMetrics()
+//	g, err := yulid.NewGenerator(yulid.WithObserver(m))
+//	http.Handle("/metrics/yulid", m)
+//
+// Metrics are not labelled by prefix, since prefixes are usually per customer
+// and would give unbounded label cardinality.
+package yulidprom
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	yulid "github.com/mikills/yul_id"
+)
+
+// buckets are the upper bounds, in seconds, of the generation latency
+// histogram. Plain generation takes a microsecond or less; the upper buckets
+// catch UniquenessChecker round trips.
+var buckets = []float64{0.000001, 0.000005, 0.00001, 0.00005, 0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1}
+
+// retryReasons are the RetryReasons counted separately
+var retryReasons = []yulid.RetryReason{yulid.RetryFiltered, yulid.RetryCollision, yulid.RetryDuplicate, yulid.RetryRejected}
+
+// errorKinds label yulid_errors_total; the last is the catch-all
+var errorKinds = []string{"invalid_input", "exhausted", "entropy", "other"}
+
+// Metrics counts Generator events. The zero value is not usable; create one
+// with NewMetrics. It is safe for concurrent use.
+type Metrics struct {
+	generated atomic.Uint64
+	calls     atomic.Uint64
+	retries   [4]atomic.Uint64 // indexed like retryReasons
+	errors    [4]atomic.Uint64 // indexed like errorKinds
+
+	latency    []atomic.Uint64 // per-bucket counts, non-cumulative
+	latencySum atomic.Uint64   // nanoseconds
+}
+
+var _ yulid.Observer = (*Metrics)(nil)
+
+// NewMetrics returns an empty Metrics
+func NewMetrics() *Metrics {
+	return &Metrics{latency: make([]atomic.Uint64, len(buckets)+1)}
+}
+
+// OnGenerate implements yulid.Observer
+func (m *Metrics) OnGenerate(_ string, count int, elapsed time.Duration) {
+	m.generated.Add(uint64(count))
+	m.calls.Add(1)
+	m.latencySum.Add(uint64(elapsed))
+
+	seconds := elapsed.Seconds()
+	i := 0
+	for i < len(buckets) && seconds > buckets[i] {
+		i++
+	}
+	m.latency[i].Add(1)
+}
+
+// OnRetry implements yulid.Observer
+func (m *Metrics) OnRetry(_ string, reason yulid.RetryReason) {
+	for i, r := range retryReasons {
+		if r == reason {
+			m.retries[i].Add(1)
+			return
+		}
+	}
+}
+
+// OnError implements yulid.Observer
+func (m *Metrics) OnError(_ string, err error) {
+	m.errors[errorKind(err)].Add(1)
+}
+
+// errorKind returns the index in errorKinds of err's label
+func errorKind(err error) int {
+	switch {
+	case errors.Is(err, yulid.ErrorInvalidInput),
+		errors.Is(err, yulid.ErrPrefixNotAllowed),
+		errors.Is(err, yulid.ErrReservedPrefix):
+		return 0
+	case errors.Is(err, yulid.ErrExhausted):
+		return 1
+	case errors.Is(err, yulid.ErrEntropy):
+		return 2
+	default:
+		return 3
+	}
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WriteTo(w)
+}
+
+// WriteTo writes the metrics to w in the Prometheus text exposition format
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+
+	fmt.Fprintln(cw, "# HELP yulid_generated_total YULIDs returned by generators.")
+	fmt.Fprintln(cw, "# TYPE yulid_generated_total counter")
+	fmt.Fprintf(cw, "yulid_generated_total %d\n", m.generated.Load())
+
+	fmt.Fprintln(cw, "# HELP yulid_retries_total Candidates discarded and regenerated, by reason.")
+	fmt.Fprintln(cw, "# TYPE yulid_retries_total counter")
+	for i, r := range retryReasons {
+		fmt.Fprintf(cw, "yulid_retries_total{reason=%q} %d\n", r, m.retries[i].Load())
+	}
+
+	fmt.Fprintln(cw, "# HELP yulid_errors_total Failed generation calls, by kind.")
+	fmt.Fprintln(cw, "# TYPE yulid_errors_total counter")
+	for i, kind := range errorKinds {
+		fmt.Fprintf(cw, "yulid_errors_total{kind=%q} %d\n", kind, m.errors[i].Load())
+	}
+
+	fmt.Fprintln(cw, "# HELP yulid_generation_seconds Duration of successful generation calls.")
+	fmt.Fprintln(cw, "# TYPE yulid_generation_seconds histogram")
+	var cumulative uint64
+	for i, le := range buckets {
+		cumulative += m.latency[i].Load()
+		fmt.Fprintf(cw, "yulid_generation_seconds_bucket{le=%q} %d\n", strconv.FormatFloat(le, 'g', -1, 64), cumulative)
+	}
+	cumulative += m.latency[len(buckets)].Load()
+	fmt.Fprintf(cw, "yulid_generation_seconds_bucket{le=\"+Inf\"} %d\n", cumulative)
+	fmt.Fprintf(cw, "yulid_generation_seconds_sum %s\n", strconv.FormatFloat(time.Duration(m.latencySum.Load()).Seconds(), 'g', -1, 64))
+	fmt.Fprintf(cw, "yulid_generation_seconds_count %d\n", cumulative)
+
+	if cw.err == nil {
+		cw.err = cw.w.Flush()
+	}
+	return cw.n, cw.err
+}
+
+// countingWriter counts bytes written and remembers the first error
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	c.err = err
+	return n, err
+}
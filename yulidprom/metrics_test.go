@@ -0,0 +1,139 @@
+package yulidprom
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	yulid "github.com/mikills/yul_id"
+)
+
+// scrape returns the samples m exposes, keyed by name and labels
+func scrape(t *testing.T, m *Metrics) map[string]string {
+	t.Helper()
+	var buf bytes.Buffer
+	n, err := m.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(buf.Len()) {
+		t.Fatalf("WriteTo returned %d, wrote %d bytes", n, buf.Len())
+	}
+
+	samples := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, value, ok := strings.Cut(line, " ")
+		if !ok {
+			t.Fatalf("malformed sample %q", line)
+		}
+		samples[name] = value
+	}
+	return samples
+}
+
+func TestMetricsFamilies(t *testing.T) {
+	w := httptest.NewRecorder()
+	NewMetrics().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type %q", ct)
+	}
+
+	body := w.Body.String()
+	for _, family := range []string{"yulid_generated_total counter", "yulid_retries_total counter", "yulid_errors_total counter", "yulid_generation_seconds histogram"} {
+		if !strings.Contains(body, "# TYPE "+family+"\n") {
+			t.Errorf("no TYPE line for %s in\n%s", family, body)
+		}
+	}
+	for _, r := range retryReasons {
+		if !strings.Contains(body, fmt.Sprintf("yulid_retries_total{reason=%q} 0\n", r)) {
+			t.Errorf("no zero sample for retry reason %v", r)
+		}
+	}
+}
+
+func TestMetricsCountGenerator(t *testing.T) {
+	m := NewMetrics()
+	rejected := false
+	g, err := yulid.NewGenerator(yulid.WithObserver(m), yulid.WithReservedPrefixes("ADMN"), yulid.WithSuffixFilter(yulid.SuffixFilterFunc(func(string, string) bool {
+		reject := !rejected
+		rejected = true
+		return reject
+	})))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := g.New("JNDE"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.NewBatch("JNDE", 10); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.New("ADMN"); err == nil {
+		t.Fatal("New accepted a reserved prefix")
+	}
+	if _, err := g.New("jn"); err == nil {
+		t.Fatal("New accepted a malformed prefix")
+	}
+
+	want := map[string]string{
+		`yulid_generated_total`:                      "11",
+		`yulid_retries_total{reason="filtered"}`:     "1",
+		`yulid_retries_total{reason="collision"}`:    "0",
+		`yulid_errors_total{kind="invalid_input"}`:   "2",
+		`yulid_errors_total{kind="exhausted"}`:       "0",
+		`yulid_generation_seconds_bucket{le="+Inf"}`: "2",
+		`yulid_generation_seconds_count`:             "2",
+	}
+	samples := scrape(t, m)
+	for name, value := range want {
+		if samples[name] != value {
+			t.Errorf("%s = %q, want %q", name, samples[name], value)
+		}
+	}
+}
+
+func TestMetricsObserver(t *testing.T) {
+	m := NewMetrics()
+	for _, r := range retryReasons {
+		m.OnRetry("JNDE", r)
+	}
+	m.OnRetry("JNDE", yulid.RetryReason(0)) // unknown reasons are dropped
+	m.OnError("JNDE", yulid.ErrExhausted)
+	m.OnError("JNDE", fmt.Errorf("read: %w", yulid.ErrEntropy))
+	m.OnError("JNDE", yulid.ErrPrefixNotAllowed)
+	m.OnError("JNDE", errors.New("checker unavailable"))
+	m.OnGenerate("JNDE", 1, 3*time.Microsecond)   // le 5e-06
+	m.OnGenerate("JNDE", 1, 700*time.Millisecond) // le 1
+	m.OnGenerate("JNDE", 1, 2*time.Second)        // +Inf only
+
+	samples := scrape(t, m)
+	for _, r := range retryReasons {
+		if name := fmt.Sprintf("yulid_retries_total{reason=%q}", r); samples[name] != "1" {
+			t.Errorf("%s = %q, want 1", name, samples[name])
+		}
+	}
+	for _, kind := range errorKinds {
+		if name := fmt.Sprintf("yulid_errors_total{kind=%q}", kind); samples[name] != "1" {
+			t.Errorf("%s = %q, want 1", name, samples[name])
+		}
+	}
+
+	cumulative := map[string]string{"1e-06": "0", "5e-06": "1", "0.5": "1", "1": "2", "+Inf": "3"}
+	for le, want := range cumulative {
+		if name := fmt.Sprintf("yulid_generation_seconds_bucket{le=%q}", le); samples[name] != want {
+			t.Errorf("%s = %q, want %q", name, samples[name], want)
+		}
+	}
+	if got := samples["yulid_generation_seconds_sum"]; got != "2.700003" {
+		t.Errorf("yulid_generation_seconds_sum = %q, want 2.700003", got)
+	}
+}